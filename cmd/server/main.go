@@ -0,0 +1,1081 @@
+// Command server runs the cambia-service HTTP/WebSocket API.
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"github.com/jason-s-yu/cambia-service/internal/admin"
+	"github.com/jason-s-yu/cambia-service/internal/api"
+	"github.com/jason-s-yu/cambia-service/internal/assets"
+	"github.com/jason-s-yu/cambia-service/internal/auth"
+	"github.com/jason-s-yu/cambia-service/internal/chat"
+	"github.com/jason-s-yu/cambia-service/internal/circuit"
+	"github.com/jason-s-yu/cambia-service/internal/digest"
+	"github.com/jason-s-yu/cambia-service/internal/flags"
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+	"github.com/jason-s-yu/cambia-service/internal/lobby"
+	"github.com/jason-s-yu/cambia-service/internal/lock"
+	"github.com/jason-s-yu/cambia-service/internal/metrics"
+	"github.com/jason-s-yu/cambia-service/internal/models"
+	"github.com/jason-s-yu/cambia-service/internal/persist"
+	"github.com/jason-s-yu/cambia-service/internal/preflight"
+	"github.com/jason-s-yu/cambia-service/internal/rating"
+	"github.com/jason-s-yu/cambia-service/internal/session"
+	"github.com/jason-s-yu/cambia-service/internal/social"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+	"github.com/jason-s-yu/cambia-service/internal/tournament"
+	"github.com/jason-s-yu/cambia-service/internal/webhook"
+	"github.com/jason-s-yu/cambia-service/internal/ws"
+)
+
+// envOr returns the environment variable's value, or fallback if it's unset
+// or empty.
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// bootstrapAdmins grants admin.Handlers.RequireAdmin access to the users
+// named in ids, a comma-separated list of user IDs (typically
+// CAMBIA_ADMIN_USER_IDS). It's the only way anything in this codebase sets
+// User.IsAdmin; without it, /admin/ops/* is permanently unreachable. An ID
+// not yet known to users is registered as a bare, non-guest User so the
+// operator doesn't need to log in through the normal flow first.
+func bootstrapAdmins(users *store.UserStore, ids string) {
+	for _, id := range strings.Split(ids, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		u, ok := users.Get(id)
+		if !ok {
+			u = &models.User{ID: id, CreatedAt: time.Now()}
+		}
+		u.IsAdmin = true
+		users.Put(u)
+	}
+}
+
+// loadResultSigner builds the ed25519 signer used to sign ranked games'
+// outcomes (see game.ResultSigner). CAMBIA_RESULT_SIGNING_SEED, if set, is a
+// hex-encoded 32-byte seed, letting the same key persist across restarts so
+// a previously-published public key keeps verifying; without it a fresh
+// key is generated every startup, which is fine for a single dev/test
+// instance but means already-issued signatures stop verifying on restart.
+func loadResultSigner() *game.ResultSigner {
+	seed := make([]byte, ed25519.SeedSize)
+	if hexSeed := os.Getenv("CAMBIA_RESULT_SIGNING_SEED"); hexSeed != "" {
+		decoded, err := hex.DecodeString(hexSeed)
+		if err != nil || len(decoded) != ed25519.SeedSize {
+			log.Fatalf("CAMBIA_RESULT_SIGNING_SEED must be %d hex-encoded bytes", ed25519.SeedSize)
+		}
+		seed = decoded
+	} else if _, err := rand.Read(seed); err != nil {
+		panic(err) // crypto/rand failing means the OS RNG is broken; nothing useful to do but stop.
+	}
+	return game.NewResultSigner(ed25519.NewKeyFromSeed(seed))
+}
+
+// voteKickData is the payload of an action_vote_kick envelope.
+type voteKickData struct {
+	TargetID string `json:"target_id"`
+}
+
+// chatData is the payload of a chat envelope.
+type chatData struct {
+	Channel chat.Channel `json:"channel"`
+	Text    string       `json:"text"`
+}
+
+// helloData is the payload of a hello envelope: a client declaring the
+// protocol version it's speaking after connecting, for the rare
+// environment that can't set Sec-WebSocket-Protocol on the handshake
+// itself (subprotocol negotiation, handled at Upgrade, is preferred).
+type helloData struct {
+	ProtocolVersion string `json:"protocol_version"`
+}
+
+// helloAckEvent confirms the protocol version this connection ended up
+// negotiated to, whether via Sec-WebSocket-Protocol or a hello envelope.
+type helloAckEvent struct {
+	Type            string `json:"type"`
+	ProtocolVersion string `json:"protocol_version"`
+}
+
+// setQuietModeData is the payload of a set_quiet_mode envelope.
+type setQuietModeData struct {
+	Quiet bool `json:"quiet"`
+}
+
+// setDormantData is the payload of a set_dormant envelope: a mobile client
+// reporting it's been backgrounded (Dormant: true) or brought back to the
+// foreground (Dormant: false).
+type setDormantData struct {
+	Dormant bool `json:"dormant"`
+}
+
+// verifyKnownSlotsData is the payload of a verify_known_slots envelope: a
+// client-side memory-aid feature asking the server to confirm which of
+// the sender's own hand slots it's actually entitled to believe it knows,
+// per Game.VerifyKnownSlots.
+type verifyKnownSlotsData struct {
+	Slots []int `json:"slots"`
+}
+
+// snapAttemptData is the payload of a snap_attempt envelope: client-side
+// instrumentation reporting how a just-resolved snap played out, so
+// snap-race fairness can be analyzed later. Won is the client's own
+// judgment of whether it made it in time; OverByMs/RTTMs let the server
+// re-derive a fairer verdict via HouseRules.SnapGrace before recording it,
+// since a snap that arrived slightly late only because of network RTT
+// shouldn't be scored the same as one that was genuinely too slow. That
+// re-derived verdict, not Won, is what HouseRules.SnapPenalty's escalating
+// draw penalty (see Game.RecordSnapOutcome) acts on.
+type snapAttemptData struct {
+	ReactionTimeMs int    `json:"reaction_time_ms"`
+	LatencyBucket  string `json:"latency_bucket"`
+	Won            bool   `json:"won"`
+	RTTMs          int    `json:"rtt_ms"`
+	OverByMs       int    `json:"over_by_ms"` // ms past the deadline the snap arrived, by the client's own clock; <=0 means on time
+}
+
+// discardActionData is the payload of a discard envelope: the card the
+// player is discarding, identified by the server-generated ID they most
+// recently learned it by (e.g. from a card_drawn event).
+type discardActionData struct {
+	CardID string `json:"card_id"`
+}
+
+// cardDrawnEvent is sent privately to the drawer after draw_stockpile or
+// draw_discard, carrying the card itself. For a stockpile draw this is the
+// only place the card's identity is ever revealed to anyone but the drawer.
+type cardDrawnEvent struct {
+	Type   string      `json:"type"`
+	GameID string      `json:"game_id"`
+	UserID string      `json:"user_id"`
+	Source string      `json:"source"` // "stockpile" or "discard"
+	Card   models.Card `json:"card"`
+}
+
+// playerDrewEvent is broadcast publicly after a draw, announcing that a
+// draw happened without revealing the card (for draw_stockpile) so other
+// players' clients can animate it without learning its identity.
+type playerDrewEvent struct {
+	Type   string `json:"type"`
+	GameID string `json:"game_id"`
+	UserID string `json:"user_id"`
+	Source string `json:"source"`
+}
+
+// playerDiscardedEvent is broadcast publicly after a discard; the card is
+// included since discarding makes its identity public knowledge.
+type playerDiscardedEvent struct {
+	Type   string      `json:"type"`
+	GameID string      `json:"game_id"`
+	UserID string      `json:"user_id"`
+	Card   models.Card `json:"card"`
+}
+
+// snapPenaltyEvent is sent privately to a player who just drew escalated
+// penalty cards for a failed snap, so their client can reflect the new
+// hand without needing to poll for it.
+type snapPenaltyEvent struct {
+	Type   string        `json:"type"`
+	GameID string        `json:"game_id"`
+	UserID string        `json:"user_id"`
+	Drawn  []models.Card `json:"drawn"`
+}
+
+// handleGameMessage is the game websocket's single entry point for every
+// inbound action. Every envelope carrying an ActionEnvelope.ActionID is
+// deduped per connection first (see Client.SeenActionID), so a client
+// retrying after a transient error can't double-apply it; the resulting
+// event or error response, where one is sent, echoes the same ActionID
+// back. action_vote_kick, action_resign, and chat mutate authoritative
+// state or need filtering; set_quiet_mode and set_dormant only flip local
+// connection/game flags and never broadcast; verify_known_slots checks a
+// client memory-aid claim and replies privately rather than broadcasting;
+// request_connection_stats replies privately with the server's current
+// view of that connection (see ws.BuildConnectionStats); hello negotiates
+// (or confirms) this connection's ProtocolVersion for a client that
+// couldn't set Sec-WebSocket-Protocol at Upgrade time, replying privately
+// with hello_ack; draw_stockpile, draw_discard, discard, snap_attempt,
+// action_resign, action_call_cambia, and action_vote_kick all go through
+// game.Game.Authorize before mutating anything; everything else is still
+// just relayed to the table as-is.
+func handleGameMessage(
+	games *store.GameStore,
+	hist *historian.Historian,
+	hub *ws.Hub,
+	lobbies map[string]*lobby.Lobby,
+	limiter *chat.RateLimiter,
+	filter chat.ProfanityFilter,
+	met *metrics.Handlers,
+	c *ws.Client,
+	msg []byte,
+) {
+	env, err := ws.ValidateEnvelope(msg)
+	if err != nil {
+		hub.Broadcast(c.GameID, msg)
+		return
+	}
+	met.ActionsProcessed.Inc()
+
+	// A client retrying after a transient websocket error may resend an
+	// action it already applied; if it tagged the action with an
+	// ActionID, drop the repeat here rather than double-applying it (e.g.
+	// double-discarding or double-snapping). The client already has
+	// whatever event/error response the original attempt produced.
+	if c.SeenActionID(env.ActionID) {
+		return
+	}
+
+	if env.Type == "chat" {
+		handleChatMessage(games, hist, hub, lobbies, limiter, filter, c, env)
+		return
+	}
+
+	if env.Type == "hello" {
+		var data helloData
+		if err := json.Unmarshal(env.Data, &data); err != nil {
+			ws.SendError(hub, c.GameID, c.UserID, ws.ErrMalformedPayload, env.Type, env.ActionID, err.Error(), false)
+			return
+		}
+		version := ws.ProtocolVersion(data.ProtocolVersion)
+		if version != "" && !ws.SupportsProtocolVersion(version) {
+			ws.SendError(hub, c.GameID, c.UserID, ws.ErrUnsupportedProtocolVersion, env.Type, env.ActionID,
+				fmt.Sprintf("unsupported protocol version %q; supported: %v", version, ws.SupportedProtocolStrings()), false)
+			return
+		}
+		if version != "" {
+			c.ProtocolVersion = version
+		}
+		if payload, err := json.Marshal(helloAckEvent{Type: "hello_ack", ProtocolVersion: string(c.ProtocolVersion)}); err == nil {
+			c.Send <- payload
+		}
+		return
+	}
+
+	if env.Type == "request_connection_stats" {
+		if payload, err := json.Marshal(ws.BuildConnectionStats(c.GameID, c)); err == nil {
+			c.Send <- payload
+		}
+		return
+	}
+
+	if env.Type == "set_quiet_mode" {
+		var data setQuietModeData
+		if err := json.Unmarshal(env.Data, &data); err == nil {
+			c.SetQuiet(data.Quiet)
+		}
+		return
+	}
+
+	if env.Type == "set_dormant" {
+		var data setDormantData
+		if err := json.Unmarshal(env.Data, &data); err == nil {
+			c.SetDormant(data.Dormant)
+			if g, ok := games.Get(c.GameID); ok {
+				g.Mu.Lock()
+				g.SetDormant(c.UserID, data.Dormant)
+				g.Mu.Unlock()
+			}
+		}
+		return
+	}
+
+	if env.Type == "verify_known_slots" {
+		var data verifyKnownSlotsData
+		if err := json.Unmarshal(env.Data, &data); err != nil {
+			ws.SendError(hub, c.GameID, c.UserID, ws.ErrMalformedPayload, env.Type, env.ActionID, err.Error(), false)
+			return
+		}
+		g, ok := games.Get(c.GameID)
+		if !ok {
+			return
+		}
+
+		g.Mu.Lock()
+		slotCardIDs := make(map[int]string, len(data.Slots))
+		for _, slot := range data.Slots {
+			if cardID, ok := g.SlotCardID(c.UserID, slot); ok {
+				slotCardIDs[slot] = cardID
+			}
+		}
+		corrected := g.VerifyKnownSlots(c.UserID, data.Slots)
+		g.Mu.Unlock()
+
+		correctedSet := make(map[int]bool, len(corrected))
+		for _, slot := range corrected {
+			correctedSet[slot] = true
+		}
+		for slot, cardID := range slotCardIDs {
+			hist.Record(c.GameID, historian.EventCardKnowledge, map[string]interface{}{
+				"user_id":      c.UserID,
+				"card_id":      cardID,
+				"known_before": true,
+				"correct":      !correctedSet[slot],
+			})
+		}
+
+		resp := map[string]interface{}{
+			"type":      "known_slots_corrected",
+			"game_id":   c.GameID,
+			"corrected": corrected,
+		}
+		if env.ActionID != "" {
+			resp["action_id"] = env.ActionID
+		}
+		if payload, err := json.Marshal(resp); err == nil {
+			hub.SendToUser(c.GameID, c.UserID, payload)
+		}
+		return
+	}
+
+	if env.Type == "snap_attempt" {
+		var data snapAttemptData
+		if err := json.Unmarshal(env.Data, &data); err == nil {
+			g, ok := games.Get(c.GameID)
+			if !ok {
+				return
+			}
+
+			g.Mu.Lock()
+			if err := g.Authorize(c.UserID, game.ActionSnap); err != nil {
+				g.Mu.Unlock()
+				if authErr, ok := err.(*game.AuthorizeError); ok {
+					ws.SendAuthorizeError(hub, c.GameID, c.UserID, env.ActionID, authErr)
+				}
+				return
+			}
+			won := g.HouseRules.SnapGrace.WithinGrace(
+				time.Duration(data.OverByMs)*time.Millisecond,
+				time.Duration(data.RTTMs)*time.Millisecond,
+			)
+			penaltyDraws := g.RecordSnapOutcome(c.UserID, won)
+			g.Mu.Unlock()
+
+			if won {
+				met.SnapSuccess.Inc()
+			} else {
+				met.SnapFail.Inc()
+			}
+			hist.Record(c.GameID, historian.EventSnapAttempt, map[string]interface{}{
+				"user_id":          c.UserID,
+				"reaction_time_ms": data.ReactionTimeMs,
+				"latency_bucket":   data.LatencyBucket,
+				"won":              won,
+				"penalty_draws":    len(penaltyDraws),
+			})
+			if len(penaltyDraws) > 0 {
+				if payload, err := json.Marshal(snapPenaltyEvent{
+					Type:   "snap_penalty",
+					GameID: c.GameID,
+					UserID: c.UserID,
+					Drawn:  penaltyDraws,
+				}); err == nil {
+					hub.SendToUser(c.GameID, c.UserID, payload)
+				}
+			}
+		}
+		hub.Broadcast(c.GameID, msg)
+		return
+	}
+
+	if env.Type == string(game.ActionResign) {
+		g, ok := games.Get(c.GameID)
+		if !ok {
+			return
+		}
+
+		g.Mu.Lock()
+		if err := g.Authorize(c.UserID, game.ActionResign); err != nil {
+			g.Mu.Unlock()
+			if authErr, ok := err.(*game.AuthorizeError); ok {
+				ws.SendAuthorizeError(hub, c.GameID, c.UserID, env.ActionID, authErr)
+			}
+			return
+		}
+		g.Resign(c.UserID)
+		gameID := g.ID
+		g.Mu.Unlock()
+
+		hist.Record(gameID, historian.EventPlayerResign, map[string]interface{}{
+			"user_id": c.UserID,
+		})
+
+		resp := map[string]interface{}{
+			"type":    "player_resign",
+			"game_id": gameID,
+			"user_id": c.UserID,
+		}
+		if env.ActionID != "" {
+			resp["action_id"] = env.ActionID
+		}
+		if payload, err := json.Marshal(resp); err == nil {
+			hub.Broadcast(gameID, payload)
+		}
+		return
+	}
+
+	if env.Type == string(game.ActionCallCambia) {
+		g, ok := games.Get(c.GameID)
+		if !ok {
+			return
+		}
+
+		g.Mu.Lock()
+		if err := g.Authorize(c.UserID, game.ActionCallCambia); err != nil {
+			g.Mu.Unlock()
+			if authErr, ok := err.(*game.AuthorizeError); ok {
+				ws.SendAuthorizeError(hub, c.GameID, c.UserID, env.ActionID, authErr)
+			}
+			return
+		}
+		g.CallCambia(c.UserID)
+		gameID := g.ID
+		g.Mu.Unlock()
+
+		hist.Record(gameID, historian.EventCambiaCalled, map[string]interface{}{
+			"user_id": c.UserID,
+		})
+
+		resp := map[string]interface{}{
+			"type":    "cambia_called",
+			"game_id": gameID,
+			"user_id": c.UserID,
+		}
+		if env.ActionID != "" {
+			resp["action_id"] = env.ActionID
+		}
+		if payload, err := json.Marshal(resp); err == nil {
+			hub.Broadcast(gameID, payload)
+		}
+		return
+	}
+
+	if env.Type == string(game.ActionDrawStockpile) || env.Type == string(game.ActionDrawDiscard) {
+		g, ok := games.Get(c.GameID)
+		if !ok {
+			return
+		}
+		action := game.ActionType(env.Type)
+
+		g.Mu.Lock()
+		if err := g.Authorize(c.UserID, action); err != nil {
+			g.Mu.Unlock()
+			if authErr, ok := err.(*game.AuthorizeError); ok {
+				ws.SendAuthorizeError(hub, c.GameID, c.UserID, env.ActionID, authErr)
+			}
+			return
+		}
+		var (
+			card    models.Card
+			drawErr error
+			source  string
+		)
+		if action == game.ActionDrawStockpile {
+			source = "stockpile"
+			card, drawErr = g.DrawStockpile(c.UserID)
+		} else {
+			source = "discard"
+			card, drawErr = g.DrawDiscard(c.UserID)
+		}
+		gameID := g.ID
+		g.Mu.Unlock()
+
+		if drawErr != nil {
+			ws.SendError(hub, c.GameID, c.UserID, game.ErrActionFailed, env.Type, env.ActionID, drawErr.Error(), false)
+			return
+		}
+
+		hist.Record(gameID, historian.EventCardDrawn, map[string]interface{}{
+			"user_id": c.UserID,
+			"source":  source,
+			"card_id": card.ID,
+		})
+
+		if payload, err := json.Marshal(playerDrewEvent{
+			Type:   "player_drew",
+			GameID: gameID,
+			UserID: c.UserID,
+			Source: source,
+		}); err == nil {
+			hub.Broadcast(gameID, payload)
+		}
+		drawnPayload, err := json.Marshal(cardDrawnEvent{
+			Type:   "card_drawn",
+			GameID: gameID,
+			UserID: c.UserID,
+			Source: source,
+			Card:   card,
+		})
+		if err == nil {
+			hub.SendToUser(gameID, c.UserID, drawnPayload)
+		}
+		return
+	}
+
+	if env.Type == string(game.ActionDiscard) {
+		g, ok := games.Get(c.GameID)
+		if !ok {
+			return
+		}
+
+		var data discardActionData
+		if err := json.Unmarshal(env.Data, &data); err != nil {
+			ws.SendError(hub, c.GameID, c.UserID, ws.ErrMalformedPayload, env.Type, env.ActionID, err.Error(), false)
+			return
+		}
+
+		g.Mu.Lock()
+		if err := g.Authorize(c.UserID, game.ActionDiscard); err != nil {
+			g.Mu.Unlock()
+			if authErr, ok := err.(*game.AuthorizeError); ok {
+				ws.SendAuthorizeError(hub, c.GameID, c.UserID, env.ActionID, authErr)
+			}
+			return
+		}
+		card, err := g.Discard(c.UserID, data.CardID)
+		if err != nil {
+			g.Mu.Unlock()
+			ws.SendError(hub, c.GameID, c.UserID, game.ErrActionFailed, env.Type, env.ActionID, err.Error(), false)
+			return
+		}
+		g.Advance()
+		gameID := g.ID
+		g.Mu.Unlock()
+
+		hist.Record(gameID, historian.EventCardDiscarded, map[string]interface{}{
+			"user_id": c.UserID,
+			"card_id": card.ID,
+		})
+
+		if payload, err := json.Marshal(playerDiscardedEvent{
+			Type:   "player_discarded",
+			GameID: gameID,
+			UserID: c.UserID,
+			Card:   card,
+		}); err == nil {
+			hub.Broadcast(gameID, payload)
+		}
+		return
+	}
+
+	if env.Type != string(game.ActionVoteKick) {
+		hub.Broadcast(c.GameID, msg)
+		return
+	}
+
+	g, ok := games.Get(c.GameID)
+	if !ok {
+		return
+	}
+
+	var data voteKickData
+	if err := json.Unmarshal(env.Data, &data); err != nil {
+		ws.SendError(hub, c.GameID, c.UserID, ws.ErrMalformedPayload, string(game.ActionVoteKick), env.ActionID, err.Error(), false)
+		return
+	}
+
+	g.Mu.Lock()
+	if err := g.Authorize(c.UserID, game.ActionVoteKick); err != nil {
+		g.Mu.Unlock()
+		if authErr, ok := err.(*game.AuthorizeError); ok {
+			ws.SendAuthorizeError(hub, c.GameID, c.UserID, env.ActionID, authErr)
+		}
+		return
+	}
+	if g.VoteKick == nil || g.VoteKick.TargetID != data.TargetID {
+		g.StartVoteKick(data.TargetID)
+	}
+	g.VoteKick.CastVote(c.UserID)
+	passed := g.ResolveVoteKick()
+	gameID := g.ID
+	g.Mu.Unlock()
+
+	hist.Record(gameID, historian.EventVoteKickResolved, map[string]interface{}{
+		"target_id": data.TargetID,
+		"voter_id":  c.UserID,
+		"passed":    passed,
+	})
+
+	voteKickResp := map[string]interface{}{
+		"type":      "vote_kick_resolved",
+		"game_id":   gameID,
+		"target_id": data.TargetID,
+		"passed":    passed,
+	}
+	if env.ActionID != "" {
+		voteKickResp["action_id"] = env.ActionID
+	}
+	if payload, err := json.Marshal(voteKickResp); err == nil {
+		hub.Broadcast(gameID, payload)
+	}
+}
+
+// handleChatMessage validates, rate-limits, filters, and delivers a single
+// in-game chat message to the channel it was sent on.
+func handleChatMessage(
+	games *store.GameStore,
+	hist *historian.Historian,
+	hub *ws.Hub,
+	lobbies map[string]*lobby.Lobby,
+	limiter *chat.RateLimiter,
+	filter chat.ProfanityFilter,
+	c *ws.Client,
+	env ws.ActionEnvelope,
+) {
+	var data chatData
+	if err := json.Unmarshal(env.Data, &data); err != nil {
+		return
+	}
+	if err := chat.Validate(data.Text); err != nil {
+		return
+	}
+	if !limiter.Allow(c.UserID) {
+		return
+	}
+	text, err := filter.Filter(data.Text)
+	if err != nil {
+		return
+	}
+	if data.Channel == "" {
+		data.Channel = chat.ChannelAll
+	}
+
+	hist.Record(c.GameID, historian.EventGameChat, map[string]interface{}{
+		"user_id": c.UserID,
+		"channel": data.Channel,
+		"text":    text,
+	})
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"type":    "game_chat",
+		"game_id": c.GameID,
+		"user_id": c.UserID,
+		"channel": data.Channel,
+		"text":    text,
+	})
+	if err != nil {
+		return
+	}
+
+	switch data.Channel {
+	case chat.ChannelDead:
+		if g, ok := games.Get(c.GameID); ok {
+			g.Mu.Lock()
+			recipients := make([]string, 0, len(g.Forfeited))
+			for userID := range g.Forfeited {
+				recipients = append(recipients, userID)
+			}
+			g.Mu.Unlock()
+			for _, userID := range recipients {
+				hub.SendToUserNonEssential(c.GameID, userID, payload)
+			}
+		}
+	case chat.ChannelSpectators:
+		if l, ok := lobbies[c.GameID]; ok {
+			for _, userID := range l.Spectators {
+				hub.SendToUserNonEssential(c.GameID, userID, payload)
+			}
+		}
+	default:
+		hub.BroadcastNonEssential(c.GameID, payload)
+	}
+}
+
+func main() {
+	if err := preflight.RunAll([]preflight.Check{
+		{Name: "build_standard_deck", Run: func() error {
+			deck := game.BuildStandardDeck(func() string { return uuid.NewString() })
+			if len(deck) != 54 {
+				return fmt.Errorf("expected 54 cards, got %d", len(deck))
+			}
+			return nil
+		}},
+	}); err != nil {
+		log.Fatalf("preflight failed, refusing to start: %v", err)
+	}
+
+	hub := ws.NewHub()
+	lobbyLocks := lock.Locker(lock.NewMemoryLocker())
+	if redisURL := os.Getenv("CAMBIA_REDIS_URL"); redisURL != "" {
+		// A Redis-backed broadcast.PubSub and lock.Locker (SET NX plus a
+		// TTL) would be wired in here so every instance behind the load
+		// balancer sees the same fan-out and the same lobby locks; no
+		// Redis client dependency is vendored in this tree yet, so for
+		// now this just surfaces that the setting was seen and ignored
+		// rather than silently running single-instance.
+		log.Printf("CAMBIA_REDIS_URL is set but no Redis-backed broadcast.PubSub/lock.Locker is wired up yet; falling back to single-instance delivery and locking")
+	}
+	games := store.NewGameStore()
+
+	snapshots, err := persist.NewFileSnapshotStore(envOr("CAMBIA_SNAPSHOT_DIR", "./data/snapshots"))
+	if err != nil {
+		log.Fatalf("failed to open snapshot store: %v", err)
+	}
+	if restored, err := persist.Recover(snapshots, games); err != nil {
+		log.Printf("snapshot recovery failed: %v", err)
+	} else if restored > 0 {
+		log.Printf("resumed %d in-flight game(s) from snapshots", restored)
+	}
+	go persist.RunSnapshotJob(games, snapshots, persist.DefaultSnapshotInterval)
+
+	// instanceID identifies this process in the cross-instance session
+	// table, so a reconnect can tell whether it landed back on the same
+	// instance or needs to catch up via events_since.
+	instanceID := envOr("CAMBIA_INSTANCE_ID", uuid.NewString())
+	sessions := session.NewMemoryStore()
+
+	chatLimiter := chat.NewRateLimiter(750 * time.Millisecond)
+	var chatFilter chat.ProfanityFilter = chat.NoopFilter{}
+
+	hub.OnDisconnect = func(gameID, userID string) {
+		if g, ok := games.Get(gameID); ok {
+			g.Mu.Lock()
+			g.OnDisconnect(userID)
+			g.Mu.Unlock()
+		}
+	}
+	// No APNs/FCM push client is vendored in this codebase, so waking a
+	// dormant player's device is just logged for now, the same stand-in
+	// used for ratingHandlers.NotifyStreakMilestone below.
+	hub.OnDormantTurnApproaching = func(gameID, userID string) {
+		log.Printf("dormant_turn_approaching: game=%s user=%s", gameID, userID)
+	}
+	hist := historian.New()
+	if antiCheatURL := os.Getenv("CAMBIA_ANTICHEAT_WEBHOOK_URL"); antiCheatURL != "" {
+		sink := webhook.NewSink(antiCheatURL)
+		hist.OnEvent = sink.Deliver
+	}
+	resultSigner := loadResultSigner()
+	rejoins := auth.NewRejoinStore()
+	users := store.NewUserStore()
+	bootstrapAdmins(users, os.Getenv("CAMBIA_ADMIN_USER_IDS"))
+	adminHandlers := &admin.Handlers{
+		Historian:  hist,
+		Games:      games,
+		DropCounts: hub.DropCounts,
+		Users:      users,
+		Broadcast:  hub.Broadcast,
+	}
+	friendGroups := social.NewGroupStore()
+	profileHandlers := &api.ProfileHandlers{Users: users}
+	lobbyHandlers := &api.LobbyHandlers{
+		Lobbies:   make(map[string]*lobby.Lobby),
+		Games:     games,
+		Groups:    friendGroups,
+		Users:     users,
+		NewID:     func() string { return uuid.NewString() },
+		NewBotID:  func() string { return uuid.NewString() },
+		Broadcast: hub.BroadcastNonEssential,
+		Locks:     lobbyLocks,
+		// No push client is vendored in this codebase (see
+		// hub.OnDormantTurnApproaching above), so a friend-group invite is
+		// just logged for now.
+		NotifyInvite: func(gameID, inviterID, inviteeID string) {
+			log.Printf("lobby_invite: game=%s from=%s to=%s", gameID, inviterID, inviteeID)
+		},
+	}
+	ratingStore := rating.NewStore()
+	if ratingFiles, err := rating.NewFileRatingStore(envOr("CAMBIA_RATINGS_DIR", "./data/ratings")); err != nil {
+		log.Printf("failed to open rating store: %v; ratings won't survive a restart", err)
+	} else {
+		ratingStore.Persist = ratingFiles
+	}
+	statsHandlers := &api.StatsHandlers{Historian: hist, Ratings: ratingStore}
+	friendHandlers := &api.FriendHandlers{
+		Friends: social.NewFriendStore(),
+		Groups:  friendGroups,
+		Users:   users,
+		NewID:   func() string { return uuid.NewString() },
+	}
+	deckPreviewHandlers := &api.DeckPreviewHandlers{
+		DeckFor: func(gameID string) ([]models.Card, bool) {
+			l, ok := lobbyHandlers.Lobbies[gameID]
+			if !ok {
+				return nil, false
+			}
+			deck, err := game.BuildDeck(l.HouseRules.Deck, func() string { return uuid.NewString() })
+			if err != nil {
+				return nil, false
+			}
+			return deck, true
+		},
+	}
+	flagHandlers := &admin.FlagHandlers{Flags: flags.New(map[string]bool{
+		"hints_mode": true,
+	})}
+	tournaments := tournament.NewStore()
+
+	hist.RunPruneJob(1*time.Hour, historian.DefaultRetention)
+
+	// timerWheel schedules turn and lobby-countdown expirations; see
+	// ws.RunLobbyCountdownScheduler below and ws.RunTurnExpiryScheduler.
+	timerWheel := ws.NewTimerWheel()
+	go timerWheel.Run(nil)
+
+	go ws.RunNudgeScheduler(hub, games)
+	go ws.RunConnectionStatsScheduler(hub, games)
+	go ws.RunLobbyCountScheduler(hub, lobbyHandlers.Lobbies)
+	go ws.RunLobbyCountdownScheduler(timerWheel, hub, lobbyHandlers.Lobbies)
+	go ws.RunTurnExpiryScheduler(timerWheel, hub, games, hist)
+	go ws.RunLengthCapScheduler(hub, games, hist)
+	go ws.RunTimeBankScheduler(hub, games)
+	go digest.RunWeeklyScheduler(users, func(userID string) digest.WeeklyDigest {
+		since := time.Now().AddDate(0, 0, -7)
+		// Rating deltas aren't tracked historically (rating.Store only
+		// holds each user's current value, not a change log), so this
+		// digest always reports 0 here until that's added.
+		return digest.Build(userID, statsHandlers.GamesForUserSince(userID, since), 0)
+	}, func(userID string, d digest.WeeklyDigest) {
+		// No email/webhook subsystem exists in this codebase yet; see
+		// digest.RunWeeklyScheduler's doc comment.
+		log.Printf("weekly_digest: user=%s games=%d wins=%d losses=%d", userID, d.GamesPlayed, d.Wins, d.Losses)
+	})
+	go ws.RunRankedScheduler(hub, games, hist, ratingStore, resultSigner)
+	go ws.RunCircuitScheduler(hub, games, hist, tournaments, ratingStore, func(t *tournament.Tournament, players []string) (string, bool) {
+		if len(players) < 2 {
+			return "", false
+		}
+		g := game.NewGameWithMode(uuid.NewString(), game.ModeCircuit)
+		rules := circuit.DefaultRules()
+		g.CircuitRules = &rules
+		for _, userID := range players {
+			g.AddPlayer(userID)
+		}
+		g.Status = game.StatusActive
+		games.Put(g)
+		return g.ID, true
+	})
+	go ws.RunDisconnectGraceScheduler(hub, games, hist)
+
+	metricsHandlers := metrics.New()
+	metricsHandlers.ActiveGames = func() int { return len(games.All()) }
+	metricsHandlers.ActiveLobbies = func() int { return len(lobbyHandlers.Lobbies) }
+	metricsHandlers.ConnectedWebsockets = hub.ConnectedCount
+	// historian.Historian.Record writes synchronously (see internal/historian),
+	// so there's no real backlog to report; see Handlers.HistorianQueueDepth.
+	metricsHandlers.HistorianQueueDepth = func() int { return 0 }
+	metricsHandlers.HistorianCommitMsLast = func() float64 { return hist.CommitStats().LastCommitMs }
+	metricsHandlers.HistorianCommitMsMax = func() float64 { return hist.CommitStats().MaxCommitMs }
+	metricsHandlers.HistorianOnEventLagMs = func() float64 { return hist.CommitStats().LastOnEventLagMs }
+	metricsHandlers.TimerWheelScheduled = func() int { return timerWheel.Stats().Scheduled }
+	metricsHandlers.TimerWheelFiredTotal = func() int64 { return timerWheel.Stats().FiredTotal }
+	metricsHandlers.TimerWheelCanceledTotal = func() int64 { return timerWheel.Stats().CanceledTotal }
+	metricsHandlers.TimerWheelLastLagMs = func() float64 { return timerWheel.Stats().LastLagMs }
+	metricsHandlers.TimerWheelMaxLagMs = func() float64 { return timerWheel.Stats().MaxLagMs }
+	// historianLagAlertThreshold is deliberately generous: Record is an
+	// in-process, synchronous, no-DB write, so anything approaching this
+	// means the process itself is under real pressure (GC, lock
+	// contention), not an external dependency being slow.
+	const historianLagAlertThreshold = 250 * time.Millisecond
+	hist.RunLagAlertJob(30*time.Second, historianLagAlertThreshold)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metricsHandlers)
+	mux.HandleFunc("/ws/game", func(w http.ResponseWriter, r *http.Request) {
+		gameID := r.URL.Query().Get("game_id")
+		userID := r.URL.Query().Get("user_id")
+
+		// A rejoin token lets an ephemeral guest resume the same identity
+		// from a different device, since they have no password to
+		// re-authenticate with.
+		if token := r.URL.Query().Get("rejoin_token"); token != "" {
+			if resolved, ok := rejoins.Resolve(token); ok {
+				userID = resolved
+			} else {
+				http.Error(w, "invalid or expired rejoin token", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		if _, ok := games.Get(gameID); !ok {
+			http.Error(w, "no such game", http.StatusNotFound)
+			return
+		}
+		conn, err := ws.Upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Printf("ws upgrade failed: %v", err)
+			return
+		}
+		// A client that requested a Sec-WebSocket-Protocol but got none
+		// back asked only for version(s) this server doesn't speak; reject
+		// with a structured error before it joins the game, rather than
+		// silently falling back to ProtocolV1 semantics it didn't ask for.
+		if requested := r.Header.Get("Sec-WebSocket-Protocol"); requested != "" && conn.Subprotocol() == "" {
+			payload, _ := json.Marshal(ws.ErrorEnvelope{
+				Type:    "error",
+				Code:    ws.ErrUnsupportedProtocolVersion,
+				Message: fmt.Sprintf("unsupported protocol version(s) %q; supported: %v", requested, ws.SupportedProtocolStrings()),
+			})
+			conn.WriteMessage(websocket.TextMessage, payload)
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "unsupported protocol version"))
+			conn.Close()
+			return
+		}
+		client := ws.NewClient(hub, gameID, userID, conn)
+
+		if g, ok := games.Get(gameID); ok {
+			g.Mu.Lock()
+			g.OnReconnect(userID)
+			privateSync, err := ws.BuildPrivateSyncState(g, userID)
+			g.Mu.Unlock()
+			if err == nil {
+				client.Send <- privateSync
+			}
+		}
+
+		// If the client is resuming (e.g. after a node failure moved the
+		// game to this instance), replay whatever it missed before it
+		// rejoins live broadcast.
+		if since := r.URL.Query().Get("last_event_id"); since != "" {
+			if sinceID, err := strconv.Atoi(since); err == nil {
+				missed := hist.EventsSince(gameID, sinceID)
+				turnID := 0
+				if g, ok := games.Get(gameID); ok {
+					g.Mu.Lock()
+					turnID = g.TurnCount
+					g.RecordResync()
+					g.Mu.Unlock()
+				}
+				if payload, err := ws.BuildEventBatch(turnID, time.Now(), missed); err == nil && payload != nil {
+					client.Send <- payload
+				}
+				client.RecordResync()
+				client.SetLastAckedEventID(sinceID)
+			}
+		}
+		sessions.Put(session.Session{
+			UserID:      userID,
+			GameID:      gameID,
+			InstanceID:  instanceID,
+			LastEventID: len(hist.Log(gameID)) - 1,
+		})
+
+		hub.Serve(client, func(c *ws.Client, msg []byte) {
+			handleGameMessage(games, hist, hub, lobbyHandlers.Lobbies, chatLimiter, chatFilter, metricsHandlers, c, msg)
+		})
+	})
+
+	mux.HandleFunc("/auth/rejoin-token", metricsHandlers.Instrument("/auth/rejoin-token", func(w http.ResponseWriter, r *http.Request) {
+		userID := r.URL.Query().Get("user_id")
+		if userID == "" {
+			http.Error(w, "user_id is required", http.StatusBadRequest)
+			return
+		}
+		token, err := rejoins.Issue(userID)
+		if err != nil {
+			http.Error(w, "failed to issue token", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rejoin_token":"` + token + `"}`))
+	}))
+
+	// instrument registers handler under pattern the same as mux.HandleFunc,
+	// additionally recording its latency against metricsHandlers so
+	// /metrics reports per-route timings without every call site below
+	// needing its own time.Now()/Observe bookkeeping.
+	instrument := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, metricsHandlers.Instrument(pattern, handler))
+	}
+
+	instrument("/admin/", adminHandlers.Dashboard)
+	instrument("/admin/games/recent-events", adminHandlers.RecentEvents)
+	instrument("/admin/games/counts", adminHandlers.GameCounts)
+	instrument("/admin/games/forensics", adminHandlers.Forensics)
+	instrument("/admin/games/anticheat", adminHandlers.Anticheat)
+	instrument("/admin/flags", flagHandlers.List)
+	instrument("/admin/flags/set", flagHandlers.Set)
+	instrument("/admin/ops/games", adminHandlers.RequireAdmin(adminHandlers.ListGames))
+	instrument("/admin/ops/games/inspect", adminHandlers.RequireAdmin(adminHandlers.InspectGame))
+	instrument("/admin/ops/games/force-end", adminHandlers.RequireAdmin(adminHandlers.ForceEnd))
+	instrument("/admin/ops/games/kick", adminHandlers.RequireAdmin(adminHandlers.Kick))
+	instrument("/admin/ops/notice", adminHandlers.RequireAdmin(adminHandlers.BroadcastNotice))
+	// Rewind is only reachable with CAMBIA_DEBUG_MODE set, since it's a
+	// reproduction aid for development/debugging, not a live-ops action
+	// meant to exist in a normal deployment; see admin.Handlers.Rewind.
+	if os.Getenv("CAMBIA_DEBUG_MODE") == "true" {
+		instrument("/admin/debug/rewind", adminHandlers.RequireAdmin(adminHandlers.Rewind))
+	}
+	instrument("/api/profile", profileHandlers.GetProfile)
+	instrument("/api/username", profileHandlers.ClaimUsername)
+	instrument("/api/notifications", profileHandlers.SetNotificationPreferences)
+	instrument("/api/notifications/digest/unsubscribe", profileHandlers.UnsubscribeWeeklyDigest)
+	instrument("/api/lobby/start-countdown", lobbyHandlers.StartCountdown)
+	instrument("/api/lobby/display-name", lobbyHandlers.SetDisplayName)
+	instrument("/api/lobby/house-rules", lobbyHandlers.SetHouseRules)
+	instrument("/lobby/join", lobbyHandlers.Join)
+	instrument("/api/lobby/invite/regenerate", lobbyHandlers.RegenerateInvite)
+	instrument("/api/lobby/invite/friend-group", lobbyHandlers.InviteFriendGroup)
+	instrument("/api/lobby/practice/start", lobbyHandlers.StartPractice)
+	instrument("/api/lobby/leave", lobbyHandlers.Leave)
+	instrument("/api/lobby/transfer-host", lobbyHandlers.TransferHost)
+	instrument("/api/stats/card-counting", statsHandlers.CardCountingSummary)
+	instrument("/api/stats/snap-race", statsHandlers.SnapRace)
+	instrument("/api/stats/games", statsHandlers.GameHistory)
+	instrument("/api/stats/personal", statsHandlers.PersonalStats)
+	instrument("/api/friends/bulk-import", friendHandlers.BulkImport)
+	instrument("/api/friends", friendHandlers.List)
+	instrument("/api/friends/groups", friendHandlers.ListGroups)
+	instrument("/api/friends/groups/create", friendHandlers.CreateGroup)
+	instrument("/api/friends/groups/update", friendHandlers.UpdateGroup)
+	instrument("/api/friends/groups/delete", friendHandlers.DeleteGroup)
+	instrument("/api/lobby/deck-preview", deckPreviewHandlers.Preview)
+	disputeHandlers := &api.DisputeHandlers{Games: games}
+	instrument("/api/dispute/raise", disputeHandlers.Raise)
+	tournamentHandlers := &api.TournamentHandlers{Tournaments: tournaments}
+	instrument("/api/tournaments/upcoming", tournamentHandlers.Upcoming)
+	instrument("/api/tournaments/standings", tournamentHandlers.Standings)
+	gameStatusHandlers := &api.GameStatusHandlers{Games: games}
+	instrument("/api/games/status", gameStatusHandlers.Get)
+	gameStateHandlers := &api.GameStateHandlers{Games: games}
+	instrument("/api/games/state/public", gameStateHandlers.Get)
+	auditHandlers := &api.AuditHandlers{Games: games, DropCounts: hub.DropCounts}
+	instrument("/api/games/audit", auditHandlers.Get)
+	replayHandlers := &api.ReplayHandlers{Historian: hist}
+	instrument("/api/games/replay", replayHandlers.Get)
+	resultsHandlers := &api.ResultsHandlers{Games: games, Signer: resultSigner}
+	instrument("/api/games/results", resultsHandlers.Get)
+	assetHandlers := &api.AssetHandlers{ManifestData: assets.DefaultManifest(
+		envOr("CAMBIA_CDN_BASE_URL", "https://cdn.cambia.example/assets"), "classic")}
+	instrument("/api/assets/manifest", assetHandlers.Manifest)
+	sessionHandlers := &api.SessionHandlers{Sessions: sessions}
+	instrument("/api/sessions", sessionHandlers.Get)
+	ratingHandlers := &api.RatingHandlers{
+		Ratings: ratingStore,
+		// There's no user-scoped push channel or notification dispatcher
+		// in this codebase yet (ws.Hub only delivers per-game, and
+		// api.NotificationPreferences is settings-only), so a streak
+		// milestone just gets logged for now rather than actually
+		// reaching a client.
+		NotifyStreakMilestone: func(userID string, streak rating.Streak, milestone int) {
+			log.Printf("streak_milestone: user=%s milestone=%d current=%d best=%d", userID, milestone, streak.Current, streak.Best)
+		},
+	}
+	instrument("/api/rating/preview", ratingHandlers.Preview)
+	instrument("/api/rating/apply-result", ratingHandlers.ApplyResult)
+
+	log.Println("cambia-service listening on :8080")
+	if err := http.ListenAndServe(":8080", mux); err != nil {
+		log.Fatal(err)
+	}
+}
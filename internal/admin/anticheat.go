@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+	"github.com/jason-s-yu/cambia-service/internal/stats"
+)
+
+// Anticheat serves a heuristic suspicious-play audit for one game, derived
+// from its EventSnapAttempt records, so an operator reviewing a report
+// doesn't have to eyeball the raw action log for implausible reaction
+// times by hand. Expects ?game_id=.
+func (h *Handlers) Anticheat(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		http.Error(w, "game_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var events []stats.SnapEvent
+	for _, e := range h.Historian.Log(gameID) {
+		if e.Type != historian.EventSnapAttempt {
+			continue
+		}
+		uid, _ := e.Payload["user_id"].(string)
+		reactionTimeMs, _ := e.Payload["reaction_time_ms"].(int)
+		won, _ := e.Payload["won"].(bool)
+		events = append(events, stats.SnapEvent{
+			UserID:         uid,
+			ReactionTimeMs: reactionTimeMs,
+			Won:            won,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats.SummarizeAnticheat(events))
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type rewindRequest struct {
+	AdminUserID string `json:"admin_user_id"`
+	GameID      string `json:"game_id"`
+	// Actions is how many of the most recent historian events to drop.
+	Actions int `json:"actions"`
+}
+
+// Rewind drops the last N historian events recorded for a game, so an
+// operator reproducing a player-reported bug can inspect the event log as
+// of N actions ago. It's registered in cmd/server/main.go only when
+// CAMBIA_DEBUG_MODE is set, since rewinding history is a debugging aid
+// that has no place reachable in a normal deployment. See
+// historian.TruncateLast's doc comment for what this does and doesn't do
+// to the corresponding live game.
+func (h *Handlers) Rewind(w http.ResponseWriter, r *http.Request) {
+	var req rewindRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GameID == "" || req.Actions <= 0 {
+		http.Error(w, "game_id and a positive actions are required", http.StatusBadRequest)
+		return
+	}
+	result := h.Historian.TruncateLast(req.GameID, req.Actions)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
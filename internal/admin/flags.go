@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/flags"
+)
+
+// FlagHandlers exposes runtime control over feature flags.
+type FlagHandlers struct {
+	Flags *flags.Service
+}
+
+type setFlagRequest struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// List serves every flag's current value.
+func (h *FlagHandlers) List(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Flags.All())
+}
+
+// Set flips a flag at runtime.
+func (h *FlagHandlers) Set(w http.ResponseWriter, r *http.Request) {
+	var req setFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	h.Flags.Set(req.Name, req.Enabled)
+	w.WriteHeader(http.StatusNoContent)
+}
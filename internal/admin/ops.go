@@ -0,0 +1,207 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+)
+
+// gameSummary is one row of ListGames, deliberately thinner than Snapshot
+// so an operator scanning for a stuck game isn't paging through full hand
+// contents before they've even picked which one to inspect.
+type gameSummary struct {
+	GameID    string      `json:"game_id"`
+	Status    game.Status `json:"status"`
+	Mode      game.Mode   `json:"mode"`
+	Players   int         `json:"players"`
+	TurnCount int         `json:"turn_count"`
+}
+
+// ListGames serves a summary of every currently non-terminal game, so an
+// operator can find a stuck game without already knowing its ID.
+func (h *Handlers) ListGames(w http.ResponseWriter, r *http.Request) {
+	var out []gameSummary
+	for _, g := range h.Games.All() {
+		g.Mu.Lock()
+		if !g.Status.Terminal() {
+			out = append(out, gameSummary{
+				GameID:    g.ID,
+				Status:    g.Status,
+				Mode:      g.Mode,
+				Players:   len(g.Players),
+				TurnCount: g.TurnCount,
+			})
+		}
+		g.Mu.Unlock()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// InspectGame serves game_id's full, unobfuscated Snapshot (real card
+// identities included), for diagnosing a specific report rather than
+// relying on whatever each player's own obfuscated view shows. Expects
+// ?game_id=.
+func (h *Handlers) InspectGame(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		http.Error(w, "game_id is required", http.StatusBadRequest)
+		return
+	}
+	g, ok := h.Games.Get(gameID)
+	if !ok {
+		http.Error(w, "no such game", http.StatusNotFound)
+		return
+	}
+	g.Mu.Lock()
+	snapshot := g.Snapshot()
+	g.Mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(snapshot)
+}
+
+type forceEndRequest struct {
+	AdminUserID string `json:"admin_user_id"`
+	GameID      string `json:"game_id"`
+	Reason      string `json:"reason"`
+}
+
+// ForceEnd marks a stuck game StatusAbandoned directly, bypassing ordinary
+// win conditions. It doesn't attempt to fold the abandoned game into
+// ratings: an operator force-ending a game is evidence that the result
+// isn't a fair outcome to score, not just a faster way to reach one.
+func (h *Handlers) ForceEnd(w http.ResponseWriter, r *http.Request) {
+	var req forceEndRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GameID == "" {
+		http.Error(w, "game_id is required", http.StatusBadRequest)
+		return
+	}
+	g, ok := h.Games.Get(req.GameID)
+	if !ok {
+		http.Error(w, "no such game", http.StatusNotFound)
+		return
+	}
+
+	g.Mu.Lock()
+	if g.Status.Terminal() {
+		g.Mu.Unlock()
+		http.Error(w, "game has already ended", http.StatusConflict)
+		return
+	}
+	g.Status = game.StatusAbandoned
+	g.Mu.Unlock()
+
+	h.Historian.Record(req.GameID, historian.EventGameForceEnded, map[string]interface{}{
+		"admin_user_id": req.AdminUserID,
+		"reason":        req.Reason,
+	})
+	if h.Broadcast != nil {
+		if payload, err := json.Marshal(gameForceEndedEvent{Type: "game_force_ended", GameID: req.GameID, Reason: req.Reason}); err == nil {
+			h.Broadcast(req.GameID, payload)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type gameForceEndedEvent struct {
+	Type   string `json:"type"`
+	GameID string `json:"game_id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type kickRequest struct {
+	AdminUserID  string `json:"admin_user_id"`
+	GameID       string `json:"game_id"`
+	TargetUserID string `json:"target_user_id"`
+	Reason       string `json:"reason"`
+}
+
+// Kick forfeits target_user_id out of game_id immediately, bypassing the
+// normal player vote-kick consensus; see Game.ForfeitPlayer.
+func (h *Handlers) Kick(w http.ResponseWriter, r *http.Request) {
+	var req kickRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GameID == "" || req.TargetUserID == "" {
+		http.Error(w, "game_id and target_user_id are required", http.StatusBadRequest)
+		return
+	}
+	g, ok := h.Games.Get(req.GameID)
+	if !ok {
+		http.Error(w, "no such game", http.StatusNotFound)
+		return
+	}
+
+	g.Mu.Lock()
+	g.ForfeitPlayer(req.TargetUserID)
+	g.Mu.Unlock()
+
+	h.Historian.Record(req.GameID, historian.EventAdminPlayerKicked, map[string]interface{}{
+		"admin_user_id":  req.AdminUserID,
+		"target_user_id": req.TargetUserID,
+		"reason":         req.Reason,
+	})
+	if h.Broadcast != nil {
+		if payload, err := json.Marshal(playerKickedEvent{Type: "player_kicked", GameID: req.GameID, UserID: req.TargetUserID, Reason: req.Reason}); err == nil {
+			h.Broadcast(req.GameID, payload)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type playerKickedEvent struct {
+	Type   string `json:"type"`
+	GameID string `json:"game_id"`
+	UserID string `json:"user_id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type broadcastNoticeRequest struct {
+	AdminUserID string `json:"admin_user_id"`
+	// GameID scopes the notice to a single game, if set. Empty means every
+	// currently non-terminal game, for a server-wide announcement (e.g.
+	// upcoming maintenance).
+	GameID  string `json:"game_id,omitempty"`
+	Message string `json:"message"`
+}
+
+type systemNoticeEvent struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// BroadcastNotice pushes a system notice into one game, or every
+// currently non-terminal game if game_id is omitted.
+func (h *Handlers) BroadcastNotice(w http.ResponseWriter, r *http.Request) {
+	var req broadcastNoticeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Message == "" {
+		http.Error(w, "message is required", http.StatusBadRequest)
+		return
+	}
+	if h.Broadcast == nil {
+		http.Error(w, "broadcast is not configured", http.StatusServiceUnavailable)
+		return
+	}
+	payload, err := json.Marshal(systemNoticeEvent{Type: "system_notice", Message: req.Message})
+	if err != nil {
+		http.Error(w, "failed to encode notice", http.StatusInternalServerError)
+		return
+	}
+
+	if req.GameID != "" {
+		h.Broadcast(req.GameID, payload)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	for _, g := range h.Games.All() {
+		g.Mu.Lock()
+		terminal := g.Status.Terminal()
+		gameID := g.ID
+		g.Mu.Unlock()
+		if !terminal {
+			h.Broadcast(gameID, payload)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,25 @@
+package admin
+
+import "net/http"
+
+// dashboardHTML is a deliberately tiny, dependency-free admin page: a form
+// to fetch a game's recent events via the existing JSON endpoint. It's not
+// meant to replace a real admin dashboard, just to avoid needing curl for
+// the most common "what happened in this game" question.
+const dashboardHTML = `<!DOCTYPE html>
+<html>
+<head><title>cambia-service admin</title></head>
+<body>
+  <h1>cambia-service admin</h1>
+  <form action="/admin/games/recent-events" method="get">
+    <label>Game ID: <input name="game_id" /></label>
+    <button type="submit">View recent events</button>
+  </form>
+</body>
+</html>`
+
+// Dashboard serves the lightweight embedded admin UI.
+func (h *Handlers) Dashboard(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(dashboardHTML))
+}
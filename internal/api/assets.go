@@ -0,0 +1,21 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/assets"
+)
+
+// AssetHandlers bundles the dependencies the asset-manifest endpoint
+// needs.
+type AssetHandlers struct {
+	ManifestData assets.Manifest
+}
+
+// Manifest serves the versioned card-sprite manifest so clients render an
+// identical deck.
+func (h *AssetHandlers) Manifest(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.ManifestData)
+}
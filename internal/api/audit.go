@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// AuditHandlers exposes a game's fairness/integrity audit report.
+type AuditHandlers struct {
+	Games *store.GameStore
+	// DropCounts returns per-user dropped-broadcast counts for a game,
+	// typically Hub.DropCounts. It's injected rather than depending on ws
+	// directly, the same reason Game.Audit itself doesn't track it.
+	DropCounts func(gameID string) map[string]int
+}
+
+// Get serves the audit report for ?game_id=, combining Game.Audit with
+// live ws drop counts so operators and suspicious players can review
+// game integrity without needing shell access to server logs.
+func (h *AuditHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		http.Error(w, "game_id is required", http.StatusBadRequest)
+		return
+	}
+	g, ok := h.Games.Get(gameID)
+	if !ok {
+		http.Error(w, "no such game", http.StatusNotFound)
+		return
+	}
+
+	var dropped map[string]int
+	if h.DropCounts != nil {
+		dropped = h.DropCounts(gameID)
+	}
+
+	g.Mu.Lock()
+	report := game.BuildAuditReport(g, dropped)
+	g.Mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
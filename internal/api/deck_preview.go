@@ -0,0 +1,30 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// DeckPreviewHandlers serves a read-only look at a lobby's configured deck
+// composition before the game starts, so players can see house-rule deck
+// changes (e.g. jokers removed) up front.
+type DeckPreviewHandlers struct {
+	// DeckFor resolves a lobby's configured deck. It's a func rather than a
+	// store reference because deck composition is derived from HouseRules,
+	// which lives with lobby config, not here.
+	DeckFor func(gameID string) ([]models.Card, bool)
+}
+
+// Preview serves the deck for ?game_id= without mutating any game state.
+func (h *DeckPreviewHandlers) Preview(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	deck, ok := h.DeckFor(gameID)
+	if !ok {
+		http.Error(w, "no such lobby", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deck)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// DisputeHandlers exposes the score dispute flow.
+type DisputeHandlers struct {
+	Games *store.GameStore
+}
+
+type raiseDisputeRequest struct {
+	GameID   string         `json:"game_id"`
+	UserID   string         `json:"user_id"`
+	Reported map[string]int `json:"reported"`
+}
+
+// Raise opens a dispute over a game's final scores. The actual recount
+// happens out of band once an operator or the engine has the final hands
+// available; this just records the claim.
+func (h *DisputeHandlers) Raise(w http.ResponseWriter, r *http.Request) {
+	var req raiseDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GameID == "" || req.UserID == "" {
+		http.Error(w, "game_id and user_id are required", http.StatusBadRequest)
+		return
+	}
+	g, ok := h.Games.Get(req.GameID)
+	if !ok {
+		http.Error(w, "no such game", http.StatusNotFound)
+		return
+	}
+	g.Mu.Lock()
+	g.RaiseDispute(req.UserID, req.Reported)
+	g.Mu.Unlock()
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Envelope is the consistent response shape for public list endpoints, so
+// clients can write one pagination loop instead of special-casing each
+// endpoint's ad-hoc shape.
+type Envelope struct {
+	Data       interface{} `json:"data"`
+	NextOffset int         `json:"next_offset,omitempty"`
+	HasMore    bool        `json:"has_more"`
+}
+
+// defaultPageSize and maxPageSize bound how much a single request can pull,
+// so an unbounded ?limit= can't be used to force a huge response.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// PageParams is the parsed offset/limit pair for a paginated request.
+type PageParams struct {
+	Offset int
+	Limit  int
+}
+
+// ParsePageParams reads ?offset= and ?limit= from the request, applying
+// defaults and clamping limit to maxPageSize.
+func ParsePageParams(r *http.Request) PageParams {
+	p := PageParams{Offset: 0, Limit: defaultPageSize}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 {
+		p.Offset = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		p.Limit = v
+	}
+	if p.Limit > maxPageSize {
+		p.Limit = maxPageSize
+	}
+	return p
+}
+
+// Paginate slices items per p, returning the page and whether more remain.
+func Paginate[T any](items []T, p PageParams) (page []T, nextOffset int, hasMore bool) {
+	if p.Offset >= len(items) {
+		return nil, p.Offset, false
+	}
+	end := p.Offset + p.Limit
+	if end > len(items) {
+		end = len(items)
+	}
+	page = items[p.Offset:end]
+	hasMore = end < len(items)
+	return page, end, hasMore
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/social"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// FriendHandlers exposes friend-list management, including bulk import and
+// saved friend groups.
+type FriendHandlers struct {
+	Friends *social.FriendStore
+	Groups  *social.GroupStore
+	Users   *store.UserStore
+	NewID   func() string
+}
+
+type bulkImportRequest struct {
+	UserID    string   `json:"user_id"`
+	Usernames []string `json:"usernames"`
+}
+
+// List serves a paginated, enveloped list of user_id's friends.
+func (h *FriendHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+	all := h.Friends.List(userID)
+	page, next, more := Paginate(all, ParsePageParams(r))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Envelope{Data: page, NextOffset: next, HasMore: more})
+}
+
+// BulkImport adds every resolvable username in the request as a friend of
+// user_id, reporting which entries were added, already friends, or
+// unresolvable.
+func (h *FriendHandlers) BulkImport(w http.ResponseWriter, r *http.Request) {
+	var req bulkImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" {
+		http.Error(w, "user_id and usernames are required", http.StatusBadRequest)
+		return
+	}
+	result := h.Friends.BulkImport(req.UserID, req.Usernames, h.Users.ResolveUsername)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+type saveGroupRequest struct {
+	UserID  string   `json:"user_id"`
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+// ListGroups serves every saved friend group owned by user_id.
+func (h *FriendHandlers) ListGroups(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Groups.List(userID))
+}
+
+// CreateGroup saves a new named friend group for user_id.
+func (h *FriendHandlers) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req saveGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.Name == "" {
+		http.Error(w, "user_id and name are required", http.StatusBadRequest)
+		return
+	}
+	g := h.Groups.Create(h.NewID(), req.UserID, req.Name, req.Members)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(g)
+}
+
+type updateGroupRequest struct {
+	GroupID string   `json:"group_id"`
+	UserID  string   `json:"user_id"`
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+// UpdateGroup renames/resets the membership of a group_id owned by
+// user_id.
+func (h *FriendHandlers) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	var req updateGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GroupID == "" || req.UserID == "" || req.Name == "" {
+		http.Error(w, "group_id, user_id, and name are required", http.StatusBadRequest)
+		return
+	}
+	if err := h.Groups.Update(req.GroupID, req.UserID, req.Name, req.Members); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type deleteGroupRequest struct {
+	GroupID string `json:"group_id"`
+	UserID  string `json:"user_id"`
+}
+
+// DeleteGroup removes group_id, so long as user_id owns it.
+func (h *FriendHandlers) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	var req deleteGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GroupID == "" || req.UserID == "" {
+		http.Error(w, "group_id and user_id are required", http.StatusBadRequest)
+		return
+	}
+	if err := h.Groups.Delete(req.GroupID, req.UserID); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
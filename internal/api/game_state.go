@@ -0,0 +1,85 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// publicStatePollInterval is the minimum gap between successive public
+// state polls for a single game, enforced per game_id rather than per
+// client since this endpoint is meant for unauthenticated embeds with no
+// stable client identity to rate-limit against.
+const publicStatePollInterval = 500 * time.Millisecond
+
+// GameStateHandlers serves the read-only public game state endpoint for
+// clients that can't hold a WebSocket (smart TVs, embeds).
+type GameStateHandlers struct {
+	Games *store.GameStore
+
+	limitMu  sync.Mutex
+	lastPoll map[string]time.Time
+}
+
+func (h *GameStateHandlers) allow(gameID string) bool {
+	h.limitMu.Lock()
+	defer h.limitMu.Unlock()
+	if h.lastPoll == nil {
+		h.lastPoll = make(map[string]time.Time)
+	}
+	now := time.Now()
+	if last, ok := h.lastPoll[gameID]; ok && now.Sub(last) < publicStatePollInterval {
+		return false
+	}
+	h.lastPoll[gameID] = now
+	return true
+}
+
+// Get serves game.PublicState for ?game_id=, refreshed from the live game
+// on demand. It's named /api/games/state/public rather than
+// /games/{id}/state/public to match this codebase's other game_id-by-query
+// endpoints (see GameStatusHandlers.Get) — this module has no path-segment
+// router. Supports If-None-Match against the response's content hash, so a
+// client polling on a fixed interval gets a cheap 304 when nothing's
+// changed, and rejects polls faster than publicStatePollInterval with 429.
+func (h *GameStateHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		http.Error(w, "game_id is required", http.StatusBadRequest)
+		return
+	}
+	g, ok := h.Games.Get(gameID)
+	if !ok {
+		http.Error(w, "no such game", http.StatusNotFound)
+		return
+	}
+	if !h.allow(gameID) {
+		http.Error(w, "polling too frequently", http.StatusTooManyRequests)
+		return
+	}
+
+	g.Mu.Lock()
+	state := g.PublicState()
+	g.Mu.Unlock()
+
+	body, err := json.Marshal(state)
+	if err != nil {
+		http.Error(w, "failed to encode state", http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// gameStatusResponse mirrors game.Status out to API consumers without
+// forcing them to import the game package.
+type gameStatusResponse struct {
+	GameID   string `json:"game_id"`
+	Status   string `json:"status"`
+	Terminal bool   `json:"terminal"`
+}
+
+// GameStatusHandlers bundles the dependencies the game-status endpoint
+// needs.
+type GameStatusHandlers struct {
+	Games *store.GameStore
+}
+
+// Get serves the current lifecycle Status for ?game_id=, so clients (and
+// matchmaking/spectator tooling) can poll lifecycle without joining the
+// websocket.
+func (h *GameStatusHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		http.Error(w, "game_id is required", http.StatusBadRequest)
+		return
+	}
+	g, ok := h.Games.Get(gameID)
+	if !ok {
+		http.Error(w, "no such game", http.StatusNotFound)
+		return
+	}
+	g.Mu.Lock()
+	status := g.Status
+	g.Mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(gameStatusResponse{
+		GameID:   gameID,
+		Status:   string(status),
+		Terminal: status.Terminal(),
+	})
+}
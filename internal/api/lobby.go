@@ -0,0 +1,462 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/lobby"
+	"github.com/jason-s-yu/cambia-service/internal/lock"
+	"github.com/jason-s-yu/cambia-service/internal/social"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// practiceDuration caps a solo practice deal so it can never outlast the
+// real game's countdown by much even if a member forgets about it.
+const practiceDuration = 2 * time.Minute
+
+// lobbyLockTTL bounds how long a single lobby mutation may hold that
+// lobby's distributed lock, so a crashed holder can't wedge it forever.
+const lobbyLockTTL = 5 * time.Second
+
+// LobbyHandlers exposes host controls over a lobby's start countdown.
+type LobbyHandlers struct {
+	Lobbies  map[string]*lobby.Lobby // gameID -> lobby; caller owns locking of the map itself
+	Games    *store.GameStore
+	Groups   *social.GroupStore
+	Users    *store.UserStore
+	NewID    func() string
+	NewBotID func() string
+	// Broadcast, if set, is used to notify a lobby's table of a host
+	// change (typically Hub.BroadcastNonEssential). It's injected rather
+	// than depending on ws directly, the same reason AuditHandlers.DropCounts
+	// is injected instead of taking a *ws.Hub.
+	Broadcast func(gameID string, payload []byte)
+	// NotifyInvite, if set, is called once per invitee when
+	// InviteFriendGroup invites them, for a user with
+	// Preferences.Notifications.LobbyInvites on. No push client is vendored
+	// in this codebase; see ratingHandlers.NotifyStreakMilestone's wiring
+	// in main.go for the same log-and-stand-in treatment.
+	NotifyInvite func(gameID, inviterID, inviteeID string)
+	// Locks guards mutations below against racing with the same lobby's
+	// mutation on another instance, once Lobbies itself is backed by
+	// shared storage rather than this process's map; nil (the default) is
+	// tolerated and just runs mutations unguarded, since a single
+	// process's h.Lobbies can't race against itself beyond what h.Lobbies'
+	// own doc comment already calls out.
+	Locks lock.Locker
+}
+
+// withLobbyLock runs fn while holding gameID's distributed lock, reporting
+// false instead of running fn if some other holder has it. With no Locks
+// configured, fn always runs directly.
+func (h *LobbyHandlers) withLobbyLock(gameID string, fn func()) (ok bool) {
+	if h.Locks == nil {
+		fn()
+		return true
+	}
+	key := "lobby:" + gameID
+	token, acquired := h.Locks.Acquire(key, lobbyLockTTL)
+	if !acquired {
+		return false
+	}
+	defer h.Locks.Release(key, token)
+	fn()
+	return true
+}
+
+// lobbyHostChangedEvent tells every client in the lobby to recompute
+// whose UI shows host controls, whether the change came from an explicit
+// transfer_host or automatic migration after the previous host left.
+type lobbyHostChangedEvent struct {
+	Type      string `json:"type"`
+	GameID    string `json:"game_id"`
+	HostID    string `json:"host_id"`
+	Automatic bool   `json:"automatic"`
+}
+
+// errorResponse is the structured JSON body written for a rejected lobby
+// request, mirroring ws.ErrorEnvelope's Code/Message fields (it carries no
+// Action field) so a client branches on Code the same way whether the
+// rejection came over the websocket or a plain REST call.
+type errorResponse struct {
+	Code    game.ErrorCode `json:"code"`
+	Message string         `json:"message,omitempty"`
+}
+
+func writeErrorResponse(w http.ResponseWriter, status int, code game.ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Code: code, Message: message})
+}
+
+func (h *LobbyHandlers) broadcastHostChanged(gameID, hostID string, automatic bool) {
+	if h.Broadcast == nil {
+		return
+	}
+	payload, err := json.Marshal(lobbyHostChangedEvent{
+		Type: "lobby_host_changed", GameID: gameID, HostID: hostID, Automatic: automatic,
+	})
+	if err == nil {
+		h.Broadcast(gameID, payload)
+	}
+}
+
+// lobbyWaitlistPromotedEvent tells the table a waitlisted joiner was
+// seated as a player, e.g. so their client can drop its spectate-only UI.
+type lobbyWaitlistPromotedEvent struct {
+	Type   string `json:"type"`
+	GameID string `json:"game_id"`
+	UserID string `json:"user_id"`
+}
+
+func (h *LobbyHandlers) broadcastWaitlistPromoted(gameID, userID string) {
+	if h.Broadcast == nil {
+		return
+	}
+	payload, err := json.Marshal(lobbyWaitlistPromotedEvent{
+		Type: "lobby_waitlist_promoted", GameID: gameID, UserID: userID,
+	})
+	if err == nil {
+		h.Broadcast(gameID, payload)
+	}
+}
+
+type startCountdownRequest struct {
+	DurationSeconds int `json:"duration_seconds,omitempty"`
+}
+
+// StartCountdown begins (or restarts) a lobby's countdown to game start,
+// optionally overriding the default duration for this lobby only.
+func (h *LobbyHandlers) StartCountdown(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	l, ok := h.Lobbies[gameID]
+	if !ok {
+		http.Error(w, "no such lobby", http.StatusNotFound)
+		return
+	}
+
+	var req startCountdownRequest
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&req)
+	}
+
+	var startAt time.Time
+	ok = h.withLobbyLock(gameID, func() {
+		if req.DurationSeconds > 0 {
+			l.SetCountdownDuration(time.Duration(req.DurationSeconds) * time.Second)
+		}
+		h.endPractice(l)
+		startAt = l.StartCountdown()
+	})
+	if !ok {
+		writeErrorResponse(w, http.StatusConflict, game.ErrLockContention, "lobby is being mutated by another request, try again")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"starts_at": startAt.Format(time.RFC3339)})
+}
+
+type setDisplayNameRequest struct {
+	UserID      string `json:"user_id"`
+	DisplayName string `json:"display_name"`
+}
+
+// SetDisplayName lets a guest replace their generated adjective-noun name
+// with one of their own choosing, colliding gracefully with existing names
+// at the table the same way the generator does.
+func (h *LobbyHandlers) SetDisplayName(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	l, ok := h.Lobbies[gameID]
+	if !ok {
+		http.Error(w, "no such lobby", http.StatusNotFound)
+		return
+	}
+
+	var req setDisplayNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.DisplayName == "" {
+		http.Error(w, "user_id and display_name are required", http.StatusBadRequest)
+		return
+	}
+
+	claimed := l.SetDisplayName(req.UserID, req.DisplayName)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"display_name": claimed})
+}
+
+// SetHouseRules lets the host configure deck composition before start.
+// Invalid configurations (e.g. too many jokers) are rejected with 400
+// rather than silently clamped.
+func (h *LobbyHandlers) SetHouseRules(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	l, ok := h.Lobbies[gameID]
+	if !ok {
+		http.Error(w, "no such lobby", http.StatusNotFound)
+		return
+	}
+
+	var rules game.HouseRules
+	if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+		http.Error(w, "invalid house rules payload", http.StatusBadRequest)
+		return
+	}
+
+	var setErr error
+	ok = h.withLobbyLock(gameID, func() {
+		setErr = l.SetHouseRules(rules)
+	})
+	if !ok {
+		writeErrorResponse(w, http.StatusConflict, game.ErrLockContention, "lobby is being mutated by another request, try again")
+		return
+	}
+	if setErr != nil {
+		http.Error(w, setErr.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Join resolves a human-typeable invite code to the lobby it belongs to.
+// It's a linear scan over h.Lobbies rather than a maintained code index,
+// which is fine at the number of concurrently open lobbies a single
+// instance is expected to host.
+func (h *LobbyHandlers) Join(w http.ResponseWriter, r *http.Request) {
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		http.Error(w, "code is required", http.StatusBadRequest)
+		return
+	}
+
+	for gameID, l := range h.Lobbies {
+		if l.InviteValid(code) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]string{"game_id": gameID})
+			return
+		}
+	}
+	http.Error(w, "invite code not found or expired", http.StatusNotFound)
+}
+
+type inviteFriendGroupRequest struct {
+	UserID  string `json:"user_id"`
+	GroupID string `json:"group_id"`
+}
+
+// inviteFriendGroupResponse lists the userIDs actually invited. The
+// inviter themself is left out of the list, harmlessly skipped rather than
+// erroring if they saved themselves into the group.
+type inviteFriendGroupResponse struct {
+	Invited []string `json:"invited"`
+}
+
+// InviteFriendGroup invites every member of user_id's saved group_id to
+// ?game_id= at once: each is recorded in the lobby as lobby.InvitePending
+// (see Lobby.Invites) and, if they have LobbyInvites notifications on,
+// NotifyInvite is called for them.
+func (h *LobbyHandlers) InviteFriendGroup(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	l, ok := h.Lobbies[gameID]
+	if !ok {
+		http.Error(w, "no such lobby", http.StatusNotFound)
+		return
+	}
+
+	var req inviteFriendGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.GroupID == "" {
+		http.Error(w, "user_id and group_id are required", http.StatusBadRequest)
+		return
+	}
+	group, ok := h.Groups.Get(req.GroupID)
+	if !ok || group.OwnerID != req.UserID {
+		http.Error(w, "no such friend group", http.StatusNotFound)
+		return
+	}
+
+	invited := make([]string, 0, len(group.Members))
+	for _, inviteeID := range group.Members {
+		if inviteeID == req.UserID {
+			continue
+		}
+		l.InviteMember(inviteeID)
+		invited = append(invited, inviteeID)
+
+		wantsNotify := true
+		if h.Users != nil {
+			if u, ok := h.Users.Get(inviteeID); ok {
+				wantsNotify = u.Preferences.Notifications.LobbyInvites
+			}
+		}
+		if wantsNotify && h.NotifyInvite != nil {
+			h.NotifyInvite(gameID, req.UserID, inviteeID)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(inviteFriendGroupResponse{Invited: invited})
+}
+
+// RegenerateInvite issues a new join code for the lobby, immediately
+// invalidating the previous one, e.g. after a host suspects it's leaked.
+func (h *LobbyHandlers) RegenerateInvite(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	l, ok := h.Lobbies[gameID]
+	if !ok {
+		http.Error(w, "no such lobby", http.StatusNotFound)
+		return
+	}
+
+	var code string
+	ok = h.withLobbyLock(gameID, func() {
+		code = l.RegenerateInviteCode()
+	})
+	if !ok {
+		writeErrorResponse(w, http.StatusConflict, game.ErrLockContention, "lobby is being mutated by another request, try again")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"invite_code": code})
+}
+
+type startPracticeResponse struct {
+	GameID string `json:"game_id"`
+}
+
+// StartPractice creates a throwaway solo practice deal against a bot for
+// a member waiting in the lobby, capped at practiceDuration via
+// HouseRules.MaxDuration so it can't run away unattended. It replaces
+// any practice game the member previously started here. Expects
+// ?game_id=&user_id=.
+//
+// The practice instance is a real ModePractice game, seated and
+// house-ruled the same way any other game would be. Nothing here drives
+// the bot's turns or deals an opening hand: this codebase has no deal
+// step, so the game starts with an empty stockpile and empty hands.
+func (h *LobbyHandlers) StartPractice(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	userID := r.URL.Query().Get("user_id")
+	if gameID == "" || userID == "" {
+		http.Error(w, "game_id and user_id are required", http.StatusBadRequest)
+		return
+	}
+	l, ok := h.Lobbies[gameID]
+	if !ok {
+		http.Error(w, "no such lobby", http.StatusNotFound)
+		return
+	}
+
+	practiceID := h.NewID()
+	ok = h.withLobbyLock(gameID, func() {
+		h.endPractice(l)
+
+		g := game.NewGameWithMode(practiceID, game.ModePractice)
+		g.HouseRules = l.HouseRules
+		g.HouseRules.MaxDuration = practiceDuration
+		g.AddPlayer(userID)
+		g.AddPlayer(lobby.BotUserID(h.NewBotID()))
+		g.Status = game.StatusActive
+		h.Games.Put(g)
+		l.SetPracticeGame(practiceID)
+	})
+	if !ok {
+		writeErrorResponse(w, http.StatusConflict, game.ErrLockContention, "lobby is being mutated by another request, try again")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(startPracticeResponse{GameID: practiceID})
+}
+
+type leaveLobbyRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// Leave removes ?game_id='s member matching the request body's user_id.
+// There's no live lobby websocket in this codebase to detect a dropped
+// connection (unlike game.Player.Connected for an in-progress game), so
+// this is the only path that currently triggers RemoveMember's automatic
+// host migration; a client is expected to call it on disconnect/leave.
+func (h *LobbyHandlers) Leave(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	l, ok := h.Lobbies[gameID]
+	if !ok {
+		http.Error(w, "no such lobby", http.StatusNotFound)
+		return
+	}
+
+	var req leaveLobbyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var newHostID, promotedUserID string
+	var migrated bool
+	ok = h.withLobbyLock(gameID, func() {
+		newHostID, migrated, promotedUserID = l.RemoveMember(req.UserID)
+	})
+	if !ok {
+		writeErrorResponse(w, http.StatusConflict, game.ErrLockContention, "lobby is being mutated by another request, try again")
+		return
+	}
+	if migrated {
+		h.broadcastHostChanged(gameID, newHostID, true)
+	}
+	if promotedUserID != "" {
+		h.broadcastWaitlistPromoted(gameID, promotedUserID)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type transferHostRequest struct {
+	UserID       string `json:"user_id"`
+	TargetUserID string `json:"target_user_id"`
+}
+
+// TransferHost lets ?game_id='s current host hand off host duties to
+// another seated member.
+func (h *LobbyHandlers) TransferHost(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	l, ok := h.Lobbies[gameID]
+	if !ok {
+		http.Error(w, "no such lobby", http.StatusNotFound)
+		return
+	}
+
+	var req transferHostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.TargetUserID == "" {
+		http.Error(w, "user_id and target_user_id are required", http.StatusBadRequest)
+		return
+	}
+
+	var transferErr error
+	ok = h.withLobbyLock(gameID, func() {
+		transferErr = l.TransferHost(req.UserID, req.TargetUserID)
+	})
+	if !ok {
+		writeErrorResponse(w, http.StatusConflict, game.ErrLockContention, "lobby is being mutated by another request, try again")
+		return
+	}
+	if transferErr != nil {
+		code := game.ErrNotHost
+		if errors.Is(transferErr, lobby.ErrNotAMember) {
+			code = game.ErrNotSeated
+		}
+		writeErrorResponse(w, http.StatusForbidden, code, transferErr.Error())
+		return
+	}
+	h.broadcastHostChanged(gameID, req.TargetUserID, false)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// endPractice tears down l's tracked practice game, if any, e.g. right
+// before the real game's countdown starts.
+func (h *LobbyHandlers) endPractice(l *lobby.Lobby) {
+	if id := l.PracticeGame(); id != "" {
+		h.Games.Delete(id)
+		l.ClearPracticeGame()
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+type setNotificationPrefsRequest struct {
+	UserID        string                         `json:"user_id"`
+	Notifications models.NotificationPreferences `json:"notifications"`
+}
+
+// SetNotificationPreferences updates a user's notification preferences.
+func (h *ProfileHandlers) SetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	var req setNotificationPrefsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+	u, ok := h.Users.Get(req.UserID)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	u.Preferences.Notifications = req.Notifications
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnsubscribeWeeklyDigest turns off ?user_id='s weekly digest, for the
+// unsubscribe link carried in the digest itself. It's intentionally a
+// single-purpose endpoint rather than routing through
+// SetNotificationPreferences, so an unsubscribe link only ever needs a
+// user_id and can't be used to silently change a user's other preferences.
+func (h *ProfileHandlers) UnsubscribeWeeklyDigest(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+	u, ok := h.Users.Get(userID)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	u.Preferences.Notifications.WeeklyDigest = false
+	w.WriteHeader(http.StatusNoContent)
+}
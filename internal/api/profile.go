@@ -0,0 +1,46 @@
+// Package api hosts the public-facing REST handlers (as opposed to the
+// websocket game protocol or the operator-only admin endpoints).
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// PublicProfile is the subset of a User's data that's safe to expose to
+// anyone, with no auth required.
+type PublicProfile struct {
+	ID        string `json:"id"`
+	Username  string `json:"username,omitempty"`
+	IsGuest   bool   `json:"is_guest"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ProfileHandlers bundles the dependencies the profile endpoints need.
+type ProfileHandlers struct {
+	Users *store.UserStore
+}
+
+// GetProfile serves a read-only public profile for ?user_id=. Guests have
+// profiles too (without a username) so links to them don't 404 mid-game.
+func (h *ProfileHandlers) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+	u, ok := h.Users.Get(userID)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PublicProfile{
+		ID:        u.ID,
+		Username:  u.Username,
+		IsGuest:   u.IsGuest,
+		CreatedAt: u.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
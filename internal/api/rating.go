@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/rating"
+)
+
+// RatingHandlers exposes rating-change previews before a ranked match and
+// resolved deltas after one ends.
+type RatingHandlers struct {
+	Ratings *rating.Store
+	// NotifyStreakMilestone, if set, is called when ApplyResult's result
+	// pushes a player's win streak to one of rating's milestone thresholds
+	// (3/5/10). There's no user-scoped push channel or notification
+	// dispatcher in this codebase yet (ws.Hub only delivers per-game), so
+	// wiring this up to something a client actually sees is left to the
+	// caller; nil skips the notification (the streak itself is still
+	// recorded).
+	NotifyStreakMilestone func(userID string, streak rating.Streak, milestone int)
+}
+
+type ratingPreviewResponse struct {
+	WinDelta  float64 `json:"win_delta"`
+	LossDelta float64 `json:"loss_delta"`
+	DrawDelta float64 `json:"draw_delta"`
+}
+
+// Preview serves the rating-change range for ?user_id= against
+// ?opponent_id= in ?mode= (default 1v1), so both players can see their
+// potential gain/loss before a ranked match starts.
+func (h *RatingHandlers) Preview(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	opponentID := r.URL.Query().Get("opponent_id")
+	if userID == "" || opponentID == "" {
+		http.Error(w, "user_id and opponent_id are required", http.StatusBadRequest)
+		return
+	}
+	mode := rating.Mode(r.URL.Query().Get("mode"))
+	if mode == "" {
+		mode = rating.Mode1v1
+	}
+
+	subject := h.Ratings.Get(mode, userID)
+	opponent := h.Ratings.Get(mode, opponentID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ratingPreviewResponse{
+		WinDelta:  rating.PreviewDelta(subject, opponent, 1),
+		LossDelta: rating.PreviewDelta(subject, opponent, 0),
+		DrawDelta: rating.PreviewDelta(subject, opponent, 0.5),
+	})
+}
+
+type applyResultRequest struct {
+	UserID     string      `json:"user_id"`
+	OpponentID string      `json:"opponent_id"`
+	Score      float64     `json:"score"`          // 1 win, 0.5 draw, 0 loss
+	Mode       rating.Mode `json:"mode,omitempty"` // defaults to rating.Mode1v1
+}
+
+type applyResultResponse struct {
+	NewRating float64       `json:"new_rating"`
+	Delta     float64       `json:"delta"`
+	Streak    rating.Streak `json:"streak"`
+}
+
+// ApplyResult records a resolved ranked match result for req.Mode
+// (defaulting to 1v1), returning the player's new rating, the delta
+// actually applied, and their updated win streak, for inclusion in the
+// game-end results payload. If this result pushes the streak to a
+// milestone (3/5/10 consecutive wins), NotifyStreakMilestone is invoked
+// before responding. Circuit-mode rounds don't go through this endpoint;
+// see rating.FinalizeRatings, called from ws.RunCircuitScheduler instead.
+func (h *RatingHandlers) ApplyResult(w http.ResponseWriter, r *http.Request) {
+	var req applyResultRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.OpponentID == "" {
+		http.Error(w, "user_id, opponent_id, and score are required", http.StatusBadRequest)
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = rating.Mode1v1
+	}
+
+	updated, delta := h.Ratings.ApplyResult(req.Mode, req.UserID, req.OpponentID, req.Score)
+	streak, milestone, hit := h.Ratings.RecordStreakResult(req.UserID, req.Score)
+	if hit && h.NotifyStreakMilestone != nil {
+		h.NotifyStreakMilestone(req.UserID, streak, milestone)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(applyResultResponse{NewRating: updated.Value, Delta: delta, Streak: streak})
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+)
+
+// ReplayHandlers bundles the dependencies the replay endpoint needs.
+type ReplayHandlers struct {
+	Historian *historian.Historian
+}
+
+// Get serves the full event replay for ?game_id=, redacted per
+// ?obfuscation= (full|public, defaulting to public so a shared replay link
+// can't be used to card-count a finished game).
+func (h *ReplayHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		http.Error(w, "game_id is required", http.StatusBadRequest)
+		return
+	}
+	mode := game.ReplayPublic
+	if r.URL.Query().Get("obfuscation") == string(game.ReplayFull) {
+		mode = game.ReplayFull
+	}
+
+	events := h.Historian.Log(gameID)
+	if len(events) == 0 {
+		http.Error(w, "no recorded history for this game", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(game.AssembleReplay(events, mode))
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// ResultsHandlers exposes a finished ranked game's signed outcome, so a
+// third-party league site can fetch and verify it independently of the
+// game_end websocket event.
+type ResultsHandlers struct {
+	Games  *store.GameStore
+	Signer *game.ResultSigner
+}
+
+type resultsResponse struct {
+	game.SignedResult
+	// PublicKey is the base64-encoded ed25519 public key Signature was
+	// produced under, included so a caller can verify without a separate
+	// round-trip to fetch it.
+	PublicKey string `json:"public_key"`
+}
+
+// Get serves ?game_id='s signed result, once it's finished. It 404s both
+// for an unknown game and for one that hasn't ended (or ended before a
+// ResultSigner was configured), rather than distinguishing the two, since
+// neither has a result to return yet.
+func (h *ResultsHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		http.Error(w, "game_id is required", http.StatusBadRequest)
+		return
+	}
+	g, ok := h.Games.Get(gameID)
+	if !ok {
+		http.Error(w, "no such game", http.StatusNotFound)
+		return
+	}
+
+	g.Mu.Lock()
+	result := g.SignedResult
+	g.Mu.Unlock()
+	if result == nil {
+		http.Error(w, "no signed result available for this game", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resultsResponse{
+		SignedResult: *result,
+		PublicKey:    base64.StdEncoding.EncodeToString(h.Signer.PublicKey()),
+	})
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/session"
+)
+
+// SessionHandlers exposes session routing lookups for whatever reverse
+// proxy sits in front of multiple server instances, so it can route a
+// reconnect to the instance that currently owns the game.
+type SessionHandlers struct {
+	Sessions session.Store
+}
+
+// Get serves the session metadata for ?user_id=&game_id=, chiefly
+// instance_id, so a proxy can route the reconnect correctly.
+func (h *SessionHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	gameID := r.URL.Query().Get("game_id")
+	if userID == "" || gameID == "" {
+		http.Error(w, "user_id and game_id are required", http.StatusBadRequest)
+		return
+	}
+	s, ok, err := h.Sessions.Get(userID, gameID)
+	if err != nil {
+		http.Error(w, "session lookup failed", http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "no session on record", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/tournament"
+)
+
+// TournamentHandlers exposes the scheduled-tournament discovery feed.
+type TournamentHandlers struct {
+	Tournaments *tournament.Store
+}
+
+// Upcoming serves upcoming tournaments, soonest first.
+func (h *TournamentHandlers) Upcoming(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Tournaments.Upcoming(time.Now()))
+}
+
+// Standings serves the running cumulative standings for ?tournament_id=,
+// for a live leaderboard view between rounds.
+func (h *TournamentHandlers) Standings(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("tournament_id")
+	t, ok := h.Tournaments.Get(id)
+	if !ok {
+		http.Error(w, "no such tournament", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t.Standings())
+}
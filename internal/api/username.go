@@ -0,0 +1,33 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+type claimUsernameRequest struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// ClaimUsername lets a user set or change their username, enforcing
+// uniqueness and the reserved-name policy.
+func (h *ProfileHandlers) ClaimUsername(w http.ResponseWriter, r *http.Request) {
+	var req claimUsernameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" || req.Username == "" {
+		http.Error(w, "user_id and username are required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.Users.ClaimUsername(req.UserID, req.Username)
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusNoContent)
+	case store.ErrUsernameTaken, store.ErrUsernameReserved:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "failed to claim username", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,60 @@
+// Package assets serves the versioned mapping from card identity to
+// renderable sprite, so every client (web, mobile, future ones) draws the
+// same deck and cosmetic unlocks stay server-authoritative.
+package assets
+
+import "github.com/jason-s-yu/cambia-service/internal/models"
+
+// ManifestVersion is bumped whenever sprite keys change shape, so clients
+// can cache the manifest and only re-fetch when it's stale.
+const ManifestVersion = 1
+
+// CardSprite describes where to find the art for one rank/suit combination.
+type CardSprite struct {
+	SpriteURL string `json:"sprite_url"`
+}
+
+// Manifest is the full versioned asset mapping served to clients.
+type Manifest struct {
+	Version    int                   `json:"version"`
+	Cards      map[string]CardSprite `json:"cards"`
+	CardBackID string                `json:"card_back_id"`
+}
+
+// cardKey is the manifest's lookup key for a card: rank and suit joined by
+// a colon, except jokers, which have no suit.
+func cardKey(rank models.Rank, suit models.Suit) string {
+	if rank == models.RankJoker {
+		return string(models.RankJoker)
+	}
+	return string(rank) + ":" + string(suit)
+}
+
+// DefaultManifest builds the manifest for the standard deck, pointing at
+// the CDN base URL. It's generated rather than hand-authored so adding a
+// rank or suit can't silently leave a sprite unmapped.
+func DefaultManifest(cdnBaseURL, defaultCardBackID string) Manifest {
+	suits := []models.Suit{models.SuitSpades, models.SuitHearts, models.SuitDiamonds, models.SuitClubs}
+	ranks := []models.Rank{
+		models.RankAce, models.RankTwo, models.RankThree, models.RankFour, models.RankFive,
+		models.RankSix, models.RankSeven, models.RankEight, models.RankNine, models.RankTen,
+		models.RankJack, models.RankQueen, models.RankKing,
+	}
+
+	cards := make(map[string]CardSprite, len(suits)*len(ranks)+1)
+	for _, suit := range suits {
+		for _, rank := range ranks {
+			key := cardKey(rank, suit)
+			cards[key] = CardSprite{SpriteURL: cdnBaseURL + "/cards/" + key + ".png"}
+		}
+	}
+	cards[cardKey(models.RankJoker, models.SuitJoker)] = CardSprite{
+		SpriteURL: cdnBaseURL + "/cards/joker.png",
+	}
+
+	return Manifest{
+		Version:    ManifestVersion,
+		Cards:      cards,
+		CardBackID: defaultCardBackID,
+	}
+}
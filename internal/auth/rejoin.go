@@ -0,0 +1,66 @@
+// Package auth handles session-adjacent concerns that don't belong to a
+// specific game: rejoin tokens, and (eventually) real account auth.
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// rejoinTokenTTL is how long a rejoin token stays valid after issuance.
+// Guests have no password to fall back on, so this is their only way back
+// into a game from a different device or after clearing local storage.
+const rejoinTokenTTL = 24 * time.Hour
+
+type rejoinEntry struct {
+	UserID    string
+	ExpiresAt time.Time
+}
+
+// RejoinStore issues and validates rejoin tokens for ephemeral guest users,
+// letting them resume a session from any device without a password.
+type RejoinStore struct {
+	mu     sync.Mutex
+	tokens map[string]rejoinEntry
+}
+
+// NewRejoinStore returns an empty RejoinStore.
+func NewRejoinStore() *RejoinStore {
+	return &RejoinStore{tokens: make(map[string]rejoinEntry)}
+}
+
+// Issue creates a new rejoin token bound to userID.
+func (s *RejoinStore) Issue(userID string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token] = rejoinEntry{UserID: userID, ExpiresAt: time.Now().Add(rejoinTokenTTL)}
+	return token, nil
+}
+
+// Resolve returns the userID bound to token if it exists and hasn't
+// expired.
+func (s *RejoinStore) Resolve(token string) (userID string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, found := s.tokens[token]
+	if !found || time.Now().After(entry.ExpiresAt) {
+		delete(s.tokens, token)
+		return "", false
+	}
+	return entry.UserID, true
+}
+
+// Revoke invalidates a token, e.g. once the guest claims a real account.
+func (s *RejoinStore) Revoke(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, token)
+}
@@ -0,0 +1,72 @@
+// Package bot implements server-driven players that occupy a game seat
+// without a websocket connection, so lobbies can start short-handed.
+package bot
+
+import "github.com/jason-s-yu/cambia-service/internal/game"
+
+// Policy decides what a bot does on its turn. It's an interface rather
+// than a single heuristic function so future policies (e.g. a
+// difficulty-tiered or learned policy) can plug into the same Bot without
+// touching the game package.
+type Policy interface {
+	// Decide picks one of g.AvailableActions(userID) for the bot to take,
+	// or reports ok=false if the bot has nothing legal to do right now.
+	Decide(g *game.Game, userID string) (action game.ActionType, ok bool)
+}
+
+// Bot is a server-driven seat: a UserID with a Policy, pluggable into a
+// Game the same way a websocket-backed player is, via Game.Authorize and
+// the same ActionType vocabulary.
+type Bot struct {
+	UserID string
+	Policy Policy
+}
+
+// New returns a Bot with the default heuristic policy.
+func New(userID string) *Bot {
+	return &Bot{UserID: userID, Policy: HeuristicPolicy{}}
+}
+
+// Act asks the bot's Policy for its move and authorizes it against g
+// before returning, so a buggy Policy can never be granted an action the
+// authorization matrix wouldn't also grant a human in the same seat.
+func (b *Bot) Act(g *game.Game) (game.ActionType, bool) {
+	action, ok := b.Policy.Decide(g, b.UserID)
+	if !ok {
+		return "", false
+	}
+	if err := g.Authorize(b.UserID, action); err != nil {
+		return "", false
+	}
+	return action, true
+}
+
+// HeuristicPolicy is a simple rule-of-thumb policy: it doesn't evaluate
+// hand composition, just picks the first action available in a fixed
+// priority order so bots make legal, unsurprising moves without needing
+// full hand visibility semantics modeled yet.
+type HeuristicPolicy struct{}
+
+// priorityOrder lists actions from most to least preferred when more than
+// one is legal. Snap is opportunistic and should be taken whenever legal;
+// call_cambia is judged last since it ends the round.
+var priorityOrder = []game.ActionType{
+	game.ActionSnap,
+	game.ActionDrawDiscard,
+	game.ActionDrawStockpile,
+	game.ActionDiscard,
+	game.ActionCallCambia,
+}
+
+// Decide implements Policy.
+func (HeuristicPolicy) Decide(g *game.Game, userID string) (game.ActionType, bool) {
+	available := g.AvailableActions(userID)
+	for _, preferred := range priorityOrder {
+		for _, a := range available {
+			if a == preferred {
+				return preferred, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,76 @@
+// Package broadcast fans game and lobby events out across server
+// instances, so a player connected to instance B still receives an event
+// published by instance A for the same game.
+package broadcast
+
+import "sync"
+
+// Message is one fanned-out event: an opaque payload scoped to a channel
+// (typically a game or lobby ID).
+type Message struct {
+	Channel string
+	Payload []byte
+}
+
+// PubSub is a cross-instance fan-out layer. The in-memory implementation
+// below only delivers within this process; a real multi-instance
+// deployment backs it with Redis (PUBLISH/SUBSCRIBE) or NATS so every
+// instance sees the same stream, but callers only depend on this
+// interface, the same way internal/session.Store decouples the session
+// table from its backing store.
+type PubSub interface {
+	Publish(channel string, payload []byte) error
+	// Subscribe registers fn to be called for every message published to
+	// channel from any instance, including this one, until the returned
+	// unsubscribe func is called.
+	Subscribe(channel string, fn func(Message)) (unsubscribe func())
+}
+
+// MemoryPubSub is the single-instance default PubSub: it delivers
+// messages to local subscribers only, with no cross-instance visibility.
+// Used in development and tests.
+type MemoryPubSub struct {
+	mu   sync.Mutex
+	subs map[string]map[int]func(Message)
+	next int
+}
+
+// NewMemoryPubSub returns an empty MemoryPubSub.
+func NewMemoryPubSub() *MemoryPubSub {
+	return &MemoryPubSub{subs: make(map[string]map[int]func(Message))}
+}
+
+// Publish delivers payload to every subscriber currently registered on
+// channel, synchronously and in no particular order.
+func (m *MemoryPubSub) Publish(channel string, payload []byte) error {
+	m.mu.Lock()
+	fns := make([]func(Message), 0, len(m.subs[channel]))
+	for _, fn := range m.subs[channel] {
+		fns = append(fns, fn)
+	}
+	m.mu.Unlock()
+
+	msg := Message{Channel: channel, Payload: payload}
+	for _, fn := range fns {
+		fn(msg)
+	}
+	return nil
+}
+
+// Subscribe registers fn for channel and returns a func that removes it.
+func (m *MemoryPubSub) Subscribe(channel string, fn func(Message)) func() {
+	m.mu.Lock()
+	if m.subs[channel] == nil {
+		m.subs[channel] = make(map[int]func(Message))
+	}
+	id := m.next
+	m.next++
+	m.subs[channel][id] = fn
+	m.mu.Unlock()
+
+	return func() {
+		m.mu.Lock()
+		delete(m.subs[channel], id)
+		m.mu.Unlock()
+	}
+}
@@ -0,0 +1,54 @@
+// Package cache provides small, generic in-memory caching primitives used
+// to avoid re-fetching data that doesn't change often, like user lookups.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// TTLCache is a concurrent-safe cache with a fixed time-to-live per entry.
+// It does no eviction beyond TTL expiry, which is fine for bounded key
+// spaces like "users looked up recently."
+type TTLCache[K comparable, V any] struct {
+	mu  sync.Mutex
+	ttl time.Duration
+	m   map[K]entry[V]
+}
+
+// NewTTLCache returns an empty cache with the given per-entry TTL.
+func NewTTLCache[K comparable, V any](ttl time.Duration) *TTLCache[K, V] {
+	return &TTLCache[K, V]{ttl: ttl, m: make(map[K]entry[V])}
+}
+
+// Get returns the cached value for key if present and not expired.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.m[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores value for key, resetting its TTL.
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = entry[V]{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// Invalidate removes key from the cache, e.g. after an update to the
+// underlying data.
+func (c *TTLCache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.m, key)
+}
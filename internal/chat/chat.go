@@ -0,0 +1,97 @@
+// Package chat implements in-game text chat: message validation, rate
+// limiting, and pluggable profanity filtering.
+package chat
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Channel scopes who a chat message is visible to.
+type Channel string
+
+const (
+	// ChannelAll is visible to every connected player and spectator.
+	ChannelAll Channel = "all"
+	// ChannelSpectators is visible only to spectators, e.g. for
+	// spectator banter that shouldn't distract or inform players.
+	ChannelSpectators Channel = "spectators"
+	// ChannelDead is visible only to players who have been eliminated
+	// or forfeited, so they can talk amongst themselves without
+	// influencing the still-active game.
+	ChannelDead Channel = "dead"
+)
+
+// maxMessageLen bounds a single chat message, since this is free text
+// typed by a player rather than a structured action payload.
+const maxMessageLen = 500
+
+// Message is a single chat message, ready to broadcast once it passes
+// validation and filtering.
+type Message struct {
+	GameID  string    `json:"game_id"`
+	UserID  string    `json:"user_id"`
+	Channel Channel   `json:"channel"`
+	Text    string    `json:"text"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// ProfanityFilter rewrites or rejects chat text before it's broadcast.
+// It's an interface rather than a single function so deployments can
+// plug in anything from a static wordlist to a third-party moderation
+// API without touching the chat package itself.
+type ProfanityFilter interface {
+	// Filter returns the (possibly censored) text to broadcast, or an
+	// error if the message should be rejected outright.
+	Filter(text string) (string, error)
+}
+
+// NoopFilter passes text through unchanged. It's the default when no
+// filter is configured, so chat works out of the box in development.
+type NoopFilter struct{}
+
+// Filter implements ProfanityFilter.
+func (NoopFilter) Filter(text string) (string, error) { return text, nil }
+
+// Validate rejects a chat message that's too long or empty before it
+// reaches rate limiting or filtering.
+func Validate(text string) error {
+	if text == "" {
+		return fmt.Errorf("chat message is empty")
+	}
+	if len(text) > maxMessageLen {
+		return fmt.Errorf("chat message exceeds %d bytes", maxMessageLen)
+	}
+	return nil
+}
+
+// RateLimiter enforces a fixed minimum gap between a user's consecutive
+// chat messages, independent of the broadcast-drop rate limiting the ws
+// hub already does for game actions: chat floods are a social problem
+// the hub's slow-client detection doesn't address.
+type RateLimiter struct {
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+	minGap   time.Duration
+}
+
+// NewRateLimiter returns a RateLimiter requiring at least minGap between
+// a single user's messages.
+func NewRateLimiter(minGap time.Duration) *RateLimiter {
+	return &RateLimiter{lastSent: make(map[string]time.Time), minGap: minGap}
+}
+
+// Allow reports whether userID may send a message right now. Only allowed
+// sends are recorded, so a burst of rejected sends doesn't reset the
+// window.
+func (r *RateLimiter) Allow(userID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if last, ok := r.lastSent[userID]; ok && now.Sub(last) < r.minGap {
+		return false
+	}
+	r.lastSent[userID] = now
+	return true
+}
@@ -0,0 +1,39 @@
+package circuit
+
+// AdjustmentTrigger names a condition, derived from a round's action log,
+// that a PointAdjustment reacts to.
+type AdjustmentTrigger string
+
+const (
+	// TriggerSnapWin applies to a player who won at least one snap this
+	// round (historian.EventSnapAttempt recorded with won=true for them).
+	TriggerSnapWin AdjustmentTrigger = "snap_win"
+	// TriggerNudged applies to a player who was nudged at least once for
+	// turn inactivity this round (Game.NudgeCounts). Game.TimeoutPolicy is
+	// now auto-enforced (see ws.RunTurnExpiryScheduler), but that fires
+	// per-turn rather than per-round, so a round-level standings penalty
+	// still keys off the nudge count as its closest available signal.
+	TriggerNudged AdjustmentTrigger = "nudged"
+)
+
+// PointAdjustment is one organizer-configured scoring rule: Points is
+// added to (or, if negative, subtracted from) a player's round score
+// whenever Trigger's condition held for them, so leagues can run house
+// scoring (e.g. a bonus for winning via snap, a penalty for stalling)
+// without code changes.
+type PointAdjustment struct {
+	Name    string            `json:"name"`
+	Trigger AdjustmentTrigger `json:"trigger"`
+	Points  int               `json:"points"`
+}
+
+// Evaluate folds every configured PointAdjustment into base given which
+// triggers held for one player this round, returning the adjusted score.
+func (r Rules) Evaluate(base int, triggered map[AdjustmentTrigger]bool) int {
+	for _, adj := range r.PointAdjustments {
+		if triggered[adj.Trigger] {
+			base += adj.Points
+		}
+	}
+	return base
+}
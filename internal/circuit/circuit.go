@@ -0,0 +1,70 @@
+// Package circuit implements "circuit mode": a sequence of games played by
+// a fixed set of players across a standings table, as used for casual
+// leagues and tournament round-robins.
+package circuit
+
+import "time"
+
+// Rules configures behavior that only applies in circuit mode, as opposed
+// to one-off games.
+type Rules struct {
+	// FreezeUserOnDisconnect, when true, puts a disconnected player's seat
+	// into a frozen state (auto-skipped, no forfeiture) instead of
+	// forfeiting them immediately, for up to MaxFrozenRounds rounds.
+	FreezeUserOnDisconnect bool
+	MaxFrozenRounds        int
+
+	// PointAdjustments are additional per-player scoring rules applied on
+	// top of raw hand score at each round's end; see adjustments.go. Nil
+	// means no adjustments, i.e. plain hand-score standings.
+	PointAdjustments []PointAdjustment
+}
+
+// DefaultRules returns the circuit defaults used when a circuit doesn't
+// specify its own.
+func DefaultRules() Rules {
+	return Rules{FreezeUserOnDisconnect: true, MaxFrozenRounds: 2}
+}
+
+// SeatStatus is the standings-visible state of one player's seat in a
+// circuit round.
+type SeatStatus string
+
+const (
+	SeatActive SeatStatus = "active"
+	SeatFrozen SeatStatus = "frozen"
+)
+
+// Seat tracks a single player's freeze state across circuit rounds.
+type Seat struct {
+	UserID       string
+	Status       SeatStatus
+	FrozenSince  time.Time
+	FrozenRounds int
+}
+
+// Freeze puts the seat into frozen state, recording when it started.
+func (s *Seat) Freeze() {
+	if s.Status == SeatFrozen {
+		return
+	}
+	s.Status = SeatFrozen
+	s.FrozenSince = time.Now()
+	s.FrozenRounds = 0
+}
+
+// Unfreeze restores the seat to active, e.g. on reconnect.
+func (s *Seat) Unfreeze() {
+	s.Status = SeatActive
+	s.FrozenRounds = 0
+}
+
+// AdvanceRound increments the seat's frozen-round count and reports whether
+// it has now exceeded rules.MaxFrozenRounds and should be forfeited.
+func (s *Seat) AdvanceRound(rules Rules) (shouldForfeit bool) {
+	if s.Status != SeatFrozen {
+		return false
+	}
+	s.FrozenRounds++
+	return s.FrozenRounds > rules.MaxFrozenRounds
+}
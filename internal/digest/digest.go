@@ -0,0 +1,44 @@
+// Package digest compiles a user's weekly personal-stats summary for the
+// opt-in weekly digest (see models.NotificationPreferences.WeeklyDigest).
+// It's built from the same historian-derived data as internal/stats and
+// internal/rating, not a dedicated job queue or email subsystem — neither
+// exists in this codebase; see RunWeeklyScheduler's doc comment for how
+// dispatch is left to the caller.
+package digest
+
+import "github.com/jason-s-yu/cambia-service/internal/stats"
+
+// WeeklyDigest is one user's compiled summary for a single week.
+type WeeklyDigest struct {
+	UserID      string  `json:"user_id"`
+	GamesPlayed int     `json:"games_played"`
+	Wins        int     `json:"wins"`
+	Losses      int     `json:"losses"`
+	RatingDelta float64 `json:"rating_delta"`
+	// BestWinGameID is the lowest-score (best) win among games, empty if
+	// there were no wins this week.
+	BestWinGameID string `json:"best_win_game_id,omitempty"`
+	BestWinScore  int    `json:"best_win_score,omitempty"`
+}
+
+// Build compiles userID's WeeklyDigest from games (already filtered to the
+// week in question by the caller) and ratingDelta, the net rating change
+// over the same window.
+func Build(userID string, games []stats.GameHistoryEntry, ratingDelta float64) WeeklyDigest {
+	d := WeeklyDigest{UserID: userID, RatingDelta: ratingDelta}
+	bestSet := false
+	for _, g := range games {
+		d.GamesPlayed++
+		if !g.Won {
+			d.Losses++
+			continue
+		}
+		d.Wins++
+		if !bestSet || g.Score < d.BestWinScore {
+			d.BestWinGameID = g.GameID
+			d.BestWinScore = g.Score
+			bestSet = true
+		}
+	}
+	return d
+}
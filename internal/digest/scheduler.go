@@ -0,0 +1,46 @@
+package digest
+
+import (
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// schedulerTickInterval is how often RunWeeklyScheduler wakes to check
+// whether it's any user's digest day; a day's resolution doesn't need
+// finer granularity than this.
+const schedulerTickInterval = time.Hour
+
+// RunWeeklyScheduler checks, once per schedulerTickInterval, every user
+// opted into NotificationPreferences.WeeklyDigest whose DigestDay matches
+// the current day, builds their WeeklyDigest via build, and hands it to
+// dispatch. There's no email/webhook subsystem in this codebase to
+// actually deliver it — dispatch is the caller's seam for wiring one in,
+// the same way ws.Hub.PubSub is the seam for a cross-instance broadcast
+// backend. A user is only dispatched to once per calendar day, tracked via
+// lastSent, so an hourly tick doesn't resend all day.
+func RunWeeklyScheduler(
+	users *store.UserStore,
+	build func(userID string) WeeklyDigest,
+	dispatch func(userID string, d WeeklyDigest),
+) {
+	ticker := time.NewTicker(schedulerTickInterval)
+	defer ticker.Stop()
+
+	lastSent := make(map[string]time.Time)
+
+	for now := range ticker.C {
+		today := now.Weekday()
+		for _, u := range users.All() {
+			prefs := u.Preferences.Notifications
+			if !prefs.WeeklyDigest || prefs.DigestDay != today {
+				continue
+			}
+			if sent, ok := lastSent[u.ID]; ok && sent.YearDay() == now.YearDay() && sent.Year() == now.Year() {
+				continue
+			}
+			lastSent[u.ID] = now
+			dispatch(u.ID, build(u.ID))
+		}
+	}
+}
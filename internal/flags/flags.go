@@ -0,0 +1,47 @@
+// Package flags provides runtime feature flags: boolean toggles that can be
+// flipped without a deploy, for staged rollouts and quick kill switches.
+package flags
+
+import "sync"
+
+// Service holds the current value of every known flag in memory. It starts
+// from defaults and is mutated via Set, typically by the admin API.
+type Service struct {
+	mu    sync.RWMutex
+	flags map[string]bool
+}
+
+// New returns a Service seeded with defaults.
+func New(defaults map[string]bool) *Service {
+	s := &Service{flags: make(map[string]bool, len(defaults))}
+	for k, v := range defaults {
+		s.flags[k] = v
+	}
+	return s
+}
+
+// Enabled reports whether a flag is on. Unknown flags default to false so a
+// typo'd flag name fails closed rather than silently enabling something.
+func (s *Service) Enabled(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.flags[name]
+}
+
+// Set flips a flag at runtime.
+func (s *Service) Set(name string, enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flags[name] = enabled
+}
+
+// All returns a snapshot of every flag's current value.
+func (s *Service) All() map[string]bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]bool, len(s.flags))
+	for k, v := range s.flags {
+		out[k] = v
+	}
+	return out
+}
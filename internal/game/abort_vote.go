@@ -0,0 +1,40 @@
+package game
+
+// AbortVote tracks in-progress consensus to abort a game early (e.g. a
+// misdeal both players notice, or a shared decision to bail). It requires
+// every connected player to agree, since a single disgruntled player
+// shouldn't be able to end someone else's game.
+type AbortVote struct {
+	Votes map[string]bool // userID -> voted to abort
+}
+
+// NewAbortVote starts an empty vote.
+func NewAbortVote() *AbortVote {
+	return &AbortVote{Votes: make(map[string]bool)}
+}
+
+// CastVote records userID's vote to abort.
+func (v *AbortVote) CastVote(userID string) {
+	v.Votes[userID] = true
+}
+
+// Passed reports whether every currently connected player has voted to
+// abort.
+func (v *AbortVote) Passed(g *Game) bool {
+	for _, p := range g.Players {
+		if !p.Connected {
+			continue
+		}
+		if !v.Votes[p.UserID] {
+			return false
+		}
+	}
+	return true
+}
+
+// StartAbortVote opens a new abort vote for the game, replacing any
+// previous one.
+func (g *Game) StartAbortVote() *AbortVote {
+	g.AbortVote = NewAbortVote()
+	return g.AbortVote
+}
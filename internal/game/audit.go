@@ -0,0 +1,54 @@
+package game
+
+// Audit accumulates the per-game integrity signals that feed a post-game
+// fairness audit: things a suspicious player or an operator investigating
+// a dispute would want to see but that aren't part of ordinary gameplay
+// state.
+type Audit struct {
+	// RNGSeed is the seed the caller used to shuffle this game's deck, set
+	// once via SeedRNG, recorded so a disputed deal can be replayed
+	// deterministically.
+	RNGSeed int64
+	// ReshuffleCount is how many times the discard pile was recycled back
+	// into the stockpile this game.
+	ReshuffleCount int
+	// DesyncResyncs is how many times a reconnecting client had to catch
+	// up via EventsSince rather than resuming cleanly, e.g. after a dropped
+	// connection outlasted its session's buffered events.
+	DesyncResyncs int
+}
+
+// SeedRNG records the seed used to shuffle g's deck. It's informational
+// only; Game itself never shuffles, callers do.
+func (g *Game) SeedRNG(seed int64) {
+	g.Audit.RNGSeed = seed
+}
+
+// RecordResync notes that a reconnecting client needed an EventsSince
+// catch-up rather than resuming from live state alone.
+func (g *Game) RecordResync() {
+	g.Audit.DesyncResyncs++
+}
+
+// AuditReport is the read-only summary of Audit plus the per-player
+// dropped-broadcast counts the ws Hub tracks, the thing Audit itself
+// can't see since Game doesn't depend on ws.
+type AuditReport struct {
+	GameID          string         `json:"game_id"`
+	RNGSeed         int64          `json:"rng_seed"`
+	ReshuffleCount  int            `json:"reshuffle_count"`
+	DesyncResyncs   int            `json:"desync_resyncs"`
+	DroppedMessages map[string]int `json:"dropped_messages"` // userID -> count
+}
+
+// BuildAuditReport assembles g's fairness audit. dropped is typically
+// Hub.DropCounts(g.ID); callers that don't track ws drops can pass nil.
+func BuildAuditReport(g *Game, dropped map[string]int) AuditReport {
+	return AuditReport{
+		GameID:          g.ID,
+		RNGSeed:         g.Audit.RNGSeed,
+		ReshuffleCount:  g.Audit.ReshuffleCount,
+		DesyncResyncs:   g.Audit.DesyncResyncs,
+		DroppedMessages: dropped,
+	}
+}
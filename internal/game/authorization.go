@@ -0,0 +1,120 @@
+package game
+
+import "fmt"
+
+// ErrorCode is a stable, machine-readable identifier for an action
+// failure. It lets a client branch on what went wrong (e.g. whether to
+// retry) without string-matching Error(), the same way historian.EventType
+// gives callers a stable key instead of a free-text description.
+type ErrorCode string
+
+const (
+	ErrUnknownAction     ErrorCode = "ERR_UNKNOWN_ACTION"
+	ErrInvalidGameStatus ErrorCode = "ERR_INVALID_GAME_STATUS"
+	ErrNotYourTurn       ErrorCode = "ERR_NOT_YOUR_TURN"
+	ErrNotSeated         ErrorCode = "ERR_NOT_SEATED"
+	// ErrNotHost is used outside the action-authorization matrix below,
+	// for host-only lobby operations like lobby.TransferHost.
+	ErrNotHost ErrorCode = "ERR_NOT_HOST"
+	// ErrLockContention is used when a mutation couldn't acquire the
+	// distributed lock guarding its target (see internal/lock), because
+	// another instance currently holds it; the caller should retry rather
+	// than treat this as a permanent rejection.
+	ErrLockContention ErrorCode = "ERR_LOCK_CONTENTION"
+	// ErrActionFailed is used outside the action-authorization matrix
+	// below, when an action passed Authorize but then failed against live
+	// game state, e.g. DrawStockpile/DrawDiscard with both piles exhausted
+	// or Discard with a card ID not in the caller's hand.
+	ErrActionFailed ErrorCode = "ERR_ACTION_FAILED"
+)
+
+// AuthorizeError is Authorize's error type: a free-text Message for logs
+// plus a stable Code a caller can use to build a structured response.
+type AuthorizeError struct {
+	Code    ErrorCode
+	Action  ActionType
+	Message string
+}
+
+func (e *AuthorizeError) Error() string { return e.Message }
+
+// ActionType names a player-initiated game action, used to key the
+// authorization matrix below.
+type ActionType string
+
+const (
+	ActionDrawStockpile ActionType = "draw_stockpile"
+	ActionDrawDiscard   ActionType = "draw_discard"
+	ActionDiscard       ActionType = "discard"
+	ActionSnap          ActionType = "snap"
+	ActionCallCambia    ActionType = "call_cambia"
+	ActionVoteKick      ActionType = "action_vote_kick"
+	ActionResign        ActionType = "action_resign"
+)
+
+// allowedStatuses is the authorization matrix: which Game.Status values
+// permit a given action. Actions not listed here are never allowed,
+// regardless of whose turn it is.
+//
+// Every ActionType here has a live WS dispatch case in cmd/server's
+// handleGameMessage that calls Authorize before mutating anything: draws
+// go through Game.DrawStockpile/Game.DrawDiscard, discards through
+// Game.Discard, and snap_attempt through Game.RecordSnapOutcome.
+var allowedStatuses = map[ActionType][]Status{
+	ActionDrawStockpile: {StatusActive, StatusFinalRound},
+	ActionDrawDiscard:   {StatusActive, StatusFinalRound},
+	ActionDiscard:       {StatusActive, StatusFinalRound},
+	ActionSnap:          {StatusActive, StatusFinalRound},
+	ActionCallCambia:    {StatusActive},
+	ActionVoteKick:      {StatusActive, StatusFinalRound},
+	ActionResign:        {StatusActive, StatusFinalRound},
+}
+
+// turnRequired lists actions that only the current player may take. Snap is
+// intentionally absent: any player can snap out of turn.
+var turnRequired = map[ActionType]bool{
+	ActionDrawStockpile: true,
+	ActionDrawDiscard:   true,
+	ActionDiscard:       true,
+	ActionCallCambia:    true,
+}
+
+// Authorize guards an action against the authorization matrix and the
+// current turn state, returning a descriptive error if userID isn't
+// permitted to take action right now.
+func (g *Game) Authorize(userID string, action ActionType) error {
+	statuses, known := allowedStatuses[action]
+	if !known {
+		return &AuthorizeError{Code: ErrUnknownAction, Action: action, Message: fmt.Sprintf("unknown action %q", action)}
+	}
+	if !statusAllowed(g.Status, statuses) {
+		return &AuthorizeError{Code: ErrInvalidGameStatus, Action: action, Message: fmt.Sprintf("action %q is not allowed while game is %q", action, g.Status)}
+	}
+	if turnRequired[action] {
+		cur := g.CurrentPlayer()
+		if cur == nil || cur.UserID != userID {
+			return &AuthorizeError{Code: ErrNotYourTurn, Action: action, Message: fmt.Sprintf("action %q requires it be %s's turn", action, userID)}
+		}
+	} else if !g.hasPlayer(userID) {
+		return &AuthorizeError{Code: ErrNotSeated, Action: action, Message: fmt.Sprintf("user %s is not seated in this game", userID)}
+	}
+	return nil
+}
+
+func statusAllowed(status Status, allowed []Status) bool {
+	for _, s := range allowed {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) hasPlayer(userID string) bool {
+	for _, p := range g.Players {
+		if p.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package game
+
+import "testing"
+
+// BenchmarkAuthorize measures the cost of the per-action authorization
+// check, since it runs on every single inbound action across every game.
+func BenchmarkAuthorize(b *testing.B) {
+	g := NewGame("g1")
+	g.Status = StatusActive
+	g.AddPlayer("u1")
+	g.AddPlayer("u2")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.Authorize("u1", ActionDrawStockpile)
+	}
+}
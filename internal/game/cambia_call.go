@@ -0,0 +1,46 @@
+package game
+
+// CambiaCall records who called "cambia" (ending the round after one more
+// lap), so a caller who disconnects afterward is still protected:
+// the round must finish per the normal rule rather than being voided by
+// their absence.
+type CambiaCall struct {
+	CallerID string
+}
+
+// CallCambia records the call and moves the game into StatusFinalRound.
+// It's idempotent: a second call in the same round is a no-op rather than
+// overwriting the original caller.
+func (g *Game) CallCambia(userID string) {
+	if g.Cambia != nil {
+		return
+	}
+	g.Cambia = &CambiaCall{CallerID: userID}
+	g.Status = StatusFinalRound
+}
+
+// CambiaCallerProtected reports whether userID is protected from forfeiture
+// while disconnected because they're the Cambia caller for this round: the
+// round has to resolve on schedule regardless of their connection state.
+func (g *Game) CambiaCallerProtected(userID string) bool {
+	return g.Cambia != nil && g.Cambia.CallerID == userID
+}
+
+// ShouldForfeitOnDisconnect reports whether a disconnecting player should
+// be forfeited immediately, accounting for circuit-mode freeze rules,
+// HouseRules.DisconnectGraceSec, and Cambia-caller protection. Callers
+// combine this with OnDisconnect. A grace-windowed freeze doesn't forfeit
+// here; it's left to whatever scheduler polls DisconnectGraceExpired once
+// the window actually runs out.
+func (g *Game) ShouldForfeitOnDisconnect(userID string) bool {
+	if g.CambiaCallerProtected(userID) {
+		return false
+	}
+	if g.CircuitRules != nil && g.CircuitRules.FreezeUserOnDisconnect {
+		return false
+	}
+	if g.HouseRules.DisconnectGraceSec > 0 {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// AliasCardID derives viewerID's stable alias for cardID within this game:
+// an HMAC of the card's real, globally-stable ID keyed by this game's
+// cardAliasKey and salted with viewerID, so the same physical card gets a
+// different-looking identifier for every viewer, and a client can't derive
+// another viewer's alias (or this game's real card ID) from one it's been
+// shown. It's deterministic per (game, viewer, card), so repeated peeks of
+// the same card always come back as the same alias.
+func (g *Game) AliasCardID(viewerID, cardID string) string {
+	mac := hmac.New(sha256.New, g.cardAliasKey[:])
+	mac.Write([]byte(viewerID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(cardID))
+	return hex.EncodeToString(mac.Sum(nil))[:24]
+}
+
+// AliasedKnowledge returns viewerID's PrivateKnowledge with both the map
+// key and each Card's ID field replaced by AliasCardID, for serializing
+// over the wire. This is the only form of a player's card knowledge that
+// should ever leave the server; PrivateKnowledge's real IDs are for
+// server-internal bookkeeping (e.g. VerifyKnownSlots, the observer
+// package's in-process training agents) where cross-viewer correlation
+// isn't a concern.
+func (g *Game) AliasedKnowledge(viewerID string) map[string]models.Card {
+	known := g.PrivateKnowledge(viewerID)
+	out := make(map[string]models.Card, len(known))
+	for cardID, card := range known {
+		alias := g.AliasCardID(viewerID, cardID)
+		card.ID = alias
+		out[alias] = card
+	}
+	return out
+}
@@ -0,0 +1,27 @@
+package game
+
+import "github.com/jason-s-yu/cambia-service/internal/models"
+
+// BuildStandardDeck returns a standard 52-card deck plus two jokers, with
+// fresh server-generated IDs. Callers that need determinism for tests
+// should not rely on card ordering; shuffle explicitly.
+func BuildStandardDeck(newID func() string) []models.Card {
+	suits := []models.Suit{models.SuitSpades, models.SuitHearts, models.SuitDiamonds, models.SuitClubs}
+	ranks := []models.Rank{
+		models.RankAce, models.RankTwo, models.RankThree, models.RankFour, models.RankFive,
+		models.RankSix, models.RankSeven, models.RankEight, models.RankNine, models.RankTen,
+		models.RankJack, models.RankQueen, models.RankKing,
+	}
+
+	deck := make([]models.Card, 0, 54)
+	for _, s := range suits {
+		for _, r := range ranks {
+			deck = append(deck, models.Card{ID: newID(), Rank: r, Suit: s})
+		}
+	}
+	deck = append(deck,
+		models.Card{ID: newID(), Rank: models.RankJoker, Suit: models.SuitJoker},
+		models.Card{ID: newID(), Rank: models.RankJoker, Suit: models.SuitJoker},
+	)
+	return deck
+}
@@ -0,0 +1,36 @@
+package game
+
+import "github.com/jason-s-yu/cambia-service/internal/models"
+
+// Dispute records a player's claim that the reported final score was
+// wrong, along with the authoritative recount the server computed when
+// asked to adjudicate.
+type Dispute struct {
+	RaisedBy string
+	Original map[string]int
+	Recount  map[string]int
+	Resolved bool
+}
+
+// RaiseDispute opens a dispute over the game's final scores. It's only
+// meaningful once the game has finished.
+func (g *Game) RaiseDispute(userID string, reported map[string]int) *Dispute {
+	d := &Dispute{RaisedBy: userID, Original: reported}
+	g.Dispute = d
+	return d
+}
+
+// Recount recomputes every player's score directly from the final hands
+// the caller passes in, independent of whatever was reported, and
+// records it as the authoritative answer to an open dispute.
+func (g *Game) Recount(hands map[string][]models.Card) map[string]int {
+	recount := make(map[string]int, len(hands))
+	for userID, cards := range hands {
+		recount[userID] = HandScore(cards)
+	}
+	if g.Dispute != nil {
+		g.Dispute.Recount = recount
+		g.Dispute.Resolved = true
+	}
+	return recount
+}
@@ -0,0 +1,60 @@
+package game
+
+import "time"
+
+// dormantGraceExtension is added to the turn timer's effective duration
+// while the current player is dormant, so a backgrounded mobile client has
+// time to receive its wake push and bring the app back to the foreground
+// before the turn times out.
+const dormantGraceExtension = 30 * time.Second
+
+// SetDormant records whether userID's client has reported itself
+// backgrounded (dormant=true) or foregrounded again (dormant=false). A
+// dormant player keeps their seat; they still receive turn-critical
+// events over the websocket (ws.Hub.Broadcast/SendToUser), but opt out of
+// non-essential ones (ws.Hub.BroadcastNonEssential) the same as quiet
+// mode, and get dormantGraceExtension added to their turn once it starts.
+func (g *Game) SetDormant(userID string, dormant bool) {
+	if !dormant {
+		delete(g.Dormant, userID)
+		return
+	}
+	if g.Dormant == nil {
+		g.Dormant = make(map[string]bool)
+	}
+	g.Dormant[userID] = true
+}
+
+// IsDormant reports whether userID is currently backgrounded.
+func (g *Game) IsDormant(userID string) bool {
+	return g.Dormant[userID]
+}
+
+// CurrentPlayerGraceExtension returns dormantGraceExtension if the current
+// player is dormant, or 0 otherwise.
+func (g *Game) CurrentPlayerGraceExtension() time.Duration {
+	p := g.CurrentPlayer()
+	if p == nil || !g.Dormant[p.UserID] {
+		return 0
+	}
+	return dormantGraceExtension
+}
+
+// TurnPastHalf reports whether the current turn is more than halfway
+// through its effective budget (Timer.Duration plus any dormant grace
+// extension).
+func (g *Game) TurnPastHalf() bool {
+	if g.Timer == nil {
+		return false
+	}
+	return g.Timer.Elapsed() >= (g.Timer.Duration+g.CurrentPlayerGraceExtension())/2
+}
+
+// TurnRemaining returns how long is left in the current turn, including
+// any dormant grace extension.
+func (g *Game) TurnRemaining() time.Duration {
+	if g.Timer == nil {
+		return 0
+	}
+	return g.Timer.Remaining() + g.CurrentPlayerGraceExtension()
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"errors"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+var (
+	// ErrPlayerNotFound is returned by a mutation targeting a userID not
+	// seated in the game. Authorize should normally catch this first via
+	// ErrNotSeated, so a caller seeing this has skipped authorization.
+	ErrPlayerNotFound = errors.New("game: player not found")
+	// ErrDeckExhausted is returned by DrawStockpile when both the stockpile
+	// and the discard pile (minus its visible top card) are empty; see
+	// StockpileExhausted.
+	ErrDeckExhausted = errors.New("game: stockpile and discard pile both exhausted")
+	// ErrDiscardEmpty is returned by DrawDiscard when there is no discard
+	// pile to draw from yet.
+	ErrDiscardEmpty = errors.New("game: discard pile is empty")
+	// ErrCardNotInHand is returned by Discard when cardID isn't currently
+	// in userID's hand, e.g. a stale or forged card ID from the client.
+	ErrCardNotInHand = errors.New("game: card is not in player's hand")
+)
+
+// DrawStockpile draws the top stockpile card into userID's hand, reshuffling
+// the discard pile back into the stockpile first if it's run dry (see
+// ReshuffleDiscardIntoStockpile). The drawn card is only known to userID
+// until they discard or it's otherwise revealed; callers must not broadcast
+// it to other players.
+func (g *Game) DrawStockpile(userID string) (models.Card, error) {
+	player := g.playerByID(userID)
+	if player == nil {
+		return models.Card{}, ErrPlayerNotFound
+	}
+	if len(g.Stockpile) == 0 {
+		if g.StockpileExhausted() {
+			return models.Card{}, ErrDeckExhausted
+		}
+		g.ReshuffleDiscardIntoStockpile(nil)
+		if len(g.Stockpile) == 0 {
+			return models.Card{}, ErrDeckExhausted
+		}
+	}
+	card := g.Stockpile[len(g.Stockpile)-1]
+	g.Stockpile = g.Stockpile[:len(g.Stockpile)-1]
+	g.Cards[card.ID] = card
+	player.Hand = append(player.Hand, card.ID)
+	player.AssignSlot(card.ID)
+	return card, nil
+}
+
+// DrawDiscard takes the top discard pile card into userID's hand. Unlike
+// DrawStockpile, the card's identity is already public (it was visible atop
+// the discard pile), so callers may broadcast it freely.
+func (g *Game) DrawDiscard(userID string) (models.Card, error) {
+	player := g.playerByID(userID)
+	if player == nil {
+		return models.Card{}, ErrPlayerNotFound
+	}
+	if len(g.DiscardPile) == 0 {
+		return models.Card{}, ErrDiscardEmpty
+	}
+	card := g.DiscardPile[len(g.DiscardPile)-1]
+	g.DiscardPile = g.DiscardPile[:len(g.DiscardPile)-1]
+	player.Hand = append(player.Hand, card.ID)
+	player.AssignSlot(card.ID)
+	return card, nil
+}
+
+// Discard moves cardID out of userID's hand onto the discard pile, clearing
+// whatever slot it occupied. It does not advance the turn; callers end the
+// turn with Advance once the discard is recorded.
+func (g *Game) Discard(userID, cardID string) (models.Card, error) {
+	player := g.playerByID(userID)
+	if player == nil {
+		return models.Card{}, ErrPlayerNotFound
+	}
+	card, ok := g.Cards[cardID]
+	if !ok || !removeCardID(&player.Hand, cardID) {
+		return models.Card{}, ErrCardNotInHand
+	}
+	player.VacateSlot(cardID)
+	g.DiscardPile = append(g.DiscardPile, card)
+	return card, nil
+}
+
+// removeCardID removes the first occurrence of cardID from ids, reporting
+// whether it was found.
+func removeCardID(ids *[]string, cardID string) bool {
+	for i, id := range *ids {
+		if id == cardID {
+			*ids = append((*ids)[:i], (*ids)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// playerByID returns the seated player with the given userID, nil if none.
+func (g *Game) playerByID(userID string) *models.Player {
+	for _, p := range g.Players {
+		if p.UserID == userID {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// TestDrawStockpileMovesCardIntoHand verifies DrawStockpile pops the top
+// stockpile card into the drawer's hand and slots, removing it from the
+// stockpile.
+func TestDrawStockpileMovesCardIntoHand(t *testing.T) {
+	g := NewGame("g1")
+	g.Status = StatusActive
+	g.AddPlayer("u1")
+	top := models.Card{ID: "c1", Rank: models.RankFive, Suit: models.SuitClubs}
+	g.Stockpile = []models.Card{{ID: "c0", Rank: models.RankAce, Suit: models.SuitSpades}, top}
+
+	card, err := g.DrawStockpile("u1")
+	if err != nil {
+		t.Fatalf("DrawStockpile: %v", err)
+	}
+	if card.ID != top.ID {
+		t.Fatalf("drew %q, want %q", card.ID, top.ID)
+	}
+	if len(g.Stockpile) != 1 {
+		t.Fatalf("stockpile len = %d, want 1", len(g.Stockpile))
+	}
+	p := g.Players[0]
+	if len(p.Hand) != 1 || p.Hand[0] != top.ID {
+		t.Fatalf("hand = %v, want [%s]", p.Hand, top.ID)
+	}
+	if len(p.Slots) != 1 || p.Slots[0] != top.ID {
+		t.Fatalf("slots = %v, want [%s]", p.Slots, top.ID)
+	}
+}
+
+// TestDrawStockpileReshufflesWhenEmpty verifies DrawStockpile recycles the
+// discard pile (minus its visible top card) when the stockpile has run dry.
+func TestDrawStockpileReshufflesWhenEmpty(t *testing.T) {
+	g := NewGame("g1")
+	g.Status = StatusActive
+	g.AddPlayer("u1")
+	g.DiscardPile = []models.Card{
+		{ID: "d1", Rank: models.RankTwo, Suit: models.SuitHearts},
+		{ID: "d2", Rank: models.RankThree, Suit: models.SuitHearts},
+	}
+
+	card, err := g.DrawStockpile("u1")
+	if err != nil {
+		t.Fatalf("DrawStockpile: %v", err)
+	}
+	if card.ID != "d1" {
+		t.Fatalf("drew %q, want d1", card.ID)
+	}
+	if len(g.DiscardPile) != 1 || g.DiscardPile[0].ID != "d2" {
+		t.Fatalf("discard pile = %v, want [d2] left on top", g.DiscardPile)
+	}
+}
+
+// TestDrawStockpileExhausted verifies DrawStockpile reports ErrDeckExhausted
+// rather than panicking when there's nothing left to draw or reshuffle.
+func TestDrawStockpileExhausted(t *testing.T) {
+	g := NewGame("g1")
+	g.Status = StatusActive
+	g.AddPlayer("u1")
+
+	if _, err := g.DrawStockpile("u1"); err != ErrDeckExhausted {
+		t.Fatalf("err = %v, want ErrDeckExhausted", err)
+	}
+}
+
+// TestDiscardRemovesCardFromHandAndSlot verifies Discard moves the card
+// from the player's hand and slots onto the discard pile.
+func TestDiscardRemovesCardFromHandAndSlot(t *testing.T) {
+	g := NewGame("g1")
+	g.Status = StatusActive
+	g.AddPlayer("u1")
+	card := models.Card{ID: "c1", Rank: models.RankSix, Suit: models.SuitDiamonds}
+	g.Cards[card.ID] = card
+	p := g.Players[0]
+	p.Hand = append(p.Hand, card.ID)
+	p.AssignSlot(card.ID)
+
+	got, err := g.Discard("u1", card.ID)
+	if err != nil {
+		t.Fatalf("Discard: %v", err)
+	}
+	if got.ID != card.ID {
+		t.Fatalf("discarded %q, want %q", got.ID, card.ID)
+	}
+	if len(p.Hand) != 0 {
+		t.Fatalf("hand = %v, want empty", p.Hand)
+	}
+	if len(p.Slots) != 1 || p.Slots[0] != "" {
+		t.Fatalf("slots = %v, want one vacated slot", p.Slots)
+	}
+	if len(g.DiscardPile) != 1 || g.DiscardPile[0].ID != card.ID {
+		t.Fatalf("discard pile = %v, want [%s]", g.DiscardPile, card.ID)
+	}
+}
+
+// TestDiscardRejectsCardNotInHand verifies Discard refuses a card ID the
+// caller doesn't actually hold, e.g. a stale or forged client-supplied ID.
+func TestDiscardRejectsCardNotInHand(t *testing.T) {
+	g := NewGame("g1")
+	g.Status = StatusActive
+	g.AddPlayer("u1")
+
+	if _, err := g.Discard("u1", "nonexistent"); err != ErrCardNotInHand {
+		t.Fatalf("err = %v, want ErrCardNotInHand", err)
+	}
+}
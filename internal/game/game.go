@@ -0,0 +1,280 @@
+// Package game implements the Cambia game engine: state, turn structure,
+// and the action handlers that mutate state in response to player input.
+package game
+
+import (
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/circuit"
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// Status is the lifecycle state of a Game. It is the single source of
+// truth for where a game is in its lifecycle; callers should branch on it
+// rather than inferring progress from ad-hoc boolean flags.
+type Status string
+
+const (
+	// StatusWaiting means the lobby hasn't started the game yet.
+	StatusWaiting Status = "waiting"
+	// StatusPregame covers the deal and initial-peek window, before the
+	// first real turn begins.
+	StatusPregame Status = "pregame"
+	// StatusActive is ordinary turn-by-turn play.
+	StatusActive Status = "active"
+	// StatusFinalRound is play after Cambia has been called: every other
+	// player gets exactly one more turn before scoring.
+	StatusFinalRound Status = "final_round"
+	// StatusCompleted means the game played out to scoring normally.
+	StatusCompleted Status = "completed"
+	// StatusAbandoned means the game ended early with no winner, e.g. an
+	// abort vote passed.
+	StatusAbandoned Status = "abandoned"
+	// StatusVoided means the game was thrown out post-hoc, e.g. a dispute
+	// resolved in favor of a recount invalidating the result.
+	StatusVoided Status = "voided"
+)
+
+// Terminal reports whether Status represents a game that will not
+// transition further on its own.
+func (s Status) Terminal() bool {
+	switch s {
+	case StatusCompleted, StatusAbandoned, StatusVoided:
+		return true
+	default:
+		return false
+	}
+}
+
+// Game holds the full authoritative state for one in-progress match. Its
+// action methods (DrawStockpile, Discard, CallCambia, ...) don't lock Mu
+// themselves; callers hold Mu for the duration of the mutation.
+type Game struct {
+	Mu sync.Mutex
+
+	ID      string
+	Status  Status
+	Players []*models.Player
+	TurnIdx int
+	// TurnCount is the total number of turns advanced this game, summed
+	// across all players, used to enforce HouseRules.MaxTurns.
+	TurnCount     int
+	Stockpile     []models.Card
+	DiscardPile   []models.Card
+	Cards         map[string]models.Card // all cards in play, by ID
+	CreatedAt     time.Time
+	Timer         *TurnTimer
+	TimeoutPolicy TimeoutPolicy
+	NudgeCounts   map[string]int // userID -> inactivity nudges sent this game
+
+	// TimeBanks is each player's chess-style time bank, lazily populated
+	// via TimeBankFor; nil/empty when HouseRules.TimeBank is disabled.
+	TimeBanks map[string]*TimeBank
+
+	// CircuitRules is non-nil when this game is part of a circuit round,
+	// and governs e.g. whether disconnects freeze a seat instead of
+	// forfeiting it.
+	CircuitRules *circuit.Rules
+	Seats        map[string]*circuit.Seat // userID -> circuit seat, circuit mode only
+
+	// AbortVote is non-nil while a unanimous-consent abort vote is open.
+	AbortVote *AbortVote
+
+	// Cambia is non-nil once a player has called "cambia" this round.
+	Cambia *CambiaCall
+
+	Mode  Mode
+	Hooks Hooks
+
+	// Dispute is non-nil while a score dispute is open or has been
+	// resolved for this game.
+	Dispute *Dispute
+
+	// Knowledge is per-player card knowledge; see knowledge.go.
+	Knowledge Knowledge
+
+	// cardAliasKey seeds this game's per-viewer card ID aliasing, so the
+	// same globally-stable card.ID can't be correlated across players (or
+	// across peeks and swaps, by a client retaining an earlier alias) from
+	// anything sent over the wire; see card_alias.go. It's generated once
+	// per game and never serialized.
+	cardAliasKey [32]byte
+
+	// HouseRules are the lobby-configured rules this game was started
+	// with; see house_rules.go.
+	HouseRules HouseRules
+
+	// Forfeited tracks players removed from play (e.g. via vote-kick)
+	// without being a normal disconnect, so scoring and turn order can
+	// treat them distinctly from a player who's merely offline.
+	Forfeited map[string]bool
+
+	// VoteKick is non-nil while a vote to remove a disruptive player is
+	// open; see vote_kick.go.
+	VoteKick *VoteKick
+
+	// Audit accumulates integrity signals for the post-game fairness
+	// report; see audit.go.
+	Audit Audit
+
+	// Dormant tracks players who've reported their client is backgrounded
+	// (mobile app suspended, not disconnected); see dormant.go.
+	Dormant map[string]bool
+
+	// FailedSnaps counts each player's consecutive failed snap attempts
+	// this game, feeding HouseRules.SnapPenalty's escalating draw count;
+	// see snap_penalty.go. A successful snap resets the counter back to 0.
+	FailedSnaps map[string]int
+
+	// RatingsFinalized is set once a ranked game's result has been folded
+	// into rating.Store, so a scheduler polling for terminal games (see
+	// ws.RunRankedScheduler) doesn't apply the same result twice.
+	RatingsFinalized bool
+
+	// SignedResult is this game's signed final outcome, set once by
+	// ws.RunRankedScheduler alongside RatingsFinalized if a ResultSigner is
+	// configured. Nil until the game ends (or if no signer is configured),
+	// so callers like api.ResultsHandlers must check before use.
+	SignedResult *SignedResult
+}
+
+// OnDisconnect handles a player's connection dropping mid-game. The seat is
+// frozen (auto-skipped, no forfeiture) rather than immediately forfeited
+// whenever either circuit mode's FreezeUserOnDisconnect or
+// HouseRules.DisconnectGraceSec applies; see ShouldForfeitOnDisconnect and
+// DisconnectGraceExpired for how the freeze eventually resolves.
+func (g *Game) OnDisconnect(userID string) {
+	for _, p := range g.Players {
+		if p.UserID == userID {
+			p.Connected = false
+		}
+	}
+	freeze := g.HouseRules.DisconnectGraceSec > 0
+	if g.CircuitRules != nil && g.CircuitRules.FreezeUserOnDisconnect {
+		freeze = true
+	}
+	if freeze {
+		if g.Seats == nil {
+			g.Seats = make(map[string]*circuit.Seat)
+		}
+		seat := g.Seats[userID]
+		if seat == nil {
+			seat = &circuit.Seat{UserID: userID}
+			g.Seats[userID] = seat
+		}
+		seat.Freeze()
+	}
+}
+
+// OnReconnect restores a player's connection and unfreezes their seat, if
+// frozen.
+func (g *Game) OnReconnect(userID string) {
+	for _, p := range g.Players {
+		if p.UserID == userID {
+			p.Connected = true
+		}
+	}
+	if seat := g.Seats[userID]; seat != nil {
+		seat.Unfreeze()
+	}
+}
+
+// IsFrozen reports whether userID's seat is currently frozen, meaning their
+// turns should be auto-skipped rather than waited on.
+func (g *Game) IsFrozen(userID string) bool {
+	seat := g.Seats[userID]
+	return seat != nil && seat.Status == circuit.SeatFrozen
+}
+
+// DisconnectGraceExpired reports whether userID's seat has been frozen
+// longer than HouseRules.DisconnectGraceSec, meaning a disconnect-driven
+// freeze should now resolve into a forfeiture. It only applies outside
+// circuit mode's own MaxFrozenRounds-based grace (see circuit.Seat.
+// AdvanceRound); a circuit seat never expires via this check.
+func (g *Game) DisconnectGraceExpired(userID string) bool {
+	if g.HouseRules.DisconnectGraceSec <= 0 {
+		return false
+	}
+	if g.CircuitRules != nil && g.CircuitRules.FreezeUserOnDisconnect {
+		return false
+	}
+	seat := g.Seats[userID]
+	if seat == nil || seat.Status != circuit.SeatFrozen {
+		return false
+	}
+	return time.Since(seat.FrozenSince) > time.Duration(g.HouseRules.DisconnectGraceSec)*time.Second
+}
+
+// NewGame creates an empty game shell. Players are added via AddPlayer;
+// nothing here deals a deck, so Stockpile and hands start empty.
+func NewGame(id string) *Game {
+	g := &Game{
+		ID:            id,
+		Status:        StatusWaiting,
+		Cards:         make(map[string]models.Card),
+		CreatedAt:     time.Now(),
+		NudgeCounts:   make(map[string]int),
+		Mode:          ModeCasual,
+		TimeoutPolicy: DefaultTimeoutPolicy(),
+		HouseRules:    DefaultHouseRules(),
+		Forfeited:     make(map[string]bool),
+	}
+	if _, err := rand.Read(g.cardAliasKey[:]); err != nil {
+		panic(err) // crypto/rand failing means the OS RNG is broken; nothing useful to do but stop.
+	}
+	return g
+}
+
+// NewGameWithMode creates a game configured for a specific mode, wiring up
+// that mode's engine hooks.
+func NewGameWithMode(id string, mode Mode) *Game {
+	g := NewGame(id)
+	g.Mode = mode
+	g.Hooks = HooksFor(mode)
+	return g
+}
+
+// AddPlayer registers a player in the game. It is not safe to call once the
+// game has started.
+func (g *Game) AddPlayer(userID string) {
+	g.AddPlayerWithName(userID, "")
+}
+
+// AddPlayerWithName registers a player with a display name carried over
+// from the lobby (guest-generated or self-chosen), so the game payload
+// never has to fall back to showing a raw user ID.
+func (g *Game) AddPlayerWithName(userID, displayName string) {
+	g.Players = append(g.Players, &models.Player{UserID: userID, DisplayName: displayName})
+}
+
+// CurrentPlayer returns the player whose turn it currently is.
+func (g *Game) CurrentPlayer() *models.Player {
+	if len(g.Players) == 0 {
+		return nil
+	}
+	return g.Players[g.TurnIdx%len(g.Players)]
+}
+
+// Advance moves the turn pointer to the next player, skipping any player
+// marked Forfeited.
+func (g *Game) Advance() {
+	if len(g.Players) == 0 {
+		return
+	}
+	if prev := g.CurrentPlayer(); prev != nil {
+		g.StopTimeBank(prev.UserID)
+	}
+	g.TurnIdx = (g.TurnIdx + 1) % len(g.Players)
+	for skipped := 1; skipped < len(g.Players) && g.Forfeited[g.Players[g.TurnIdx].UserID]; skipped++ {
+		g.TurnIdx = (g.TurnIdx + 1) % len(g.Players)
+	}
+	g.TurnCount++
+	if g.Timer != nil {
+		g.Timer.Reset()
+	}
+	if cur := g.CurrentPlayer(); cur != nil {
+		g.StartTimeBank(cur.UserID)
+	}
+}
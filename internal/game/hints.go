@@ -0,0 +1,32 @@
+package game
+
+// HintsMode, when enabled on a lobby, has the server suggest a reasonable
+// next action to casual players (e.g. "swap looks safe here") rather than
+// leaving them to guess at strategy. It's opt-in per lobby, not per player,
+// since advice visible to one player but not another would be unfair.
+type HintsMode struct {
+	Enabled bool
+}
+
+// Hint is a single piece of coaching advice surfaced to a player for their
+// current decision point. It deliberately doesn't reveal hidden
+// information (e.g. it won't say "that's a King"), only strategic framing
+// the player could derive themselves given more experience.
+type Hint struct {
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+// SuggestDrawSource recommends drawing from the stockpile or discard pile
+// based only on publicly known information (the top discard card's value),
+// suitable for a casual "opening book" style hint.
+func (g *Game) SuggestDrawSource() Hint {
+	if len(g.DiscardPile) == 0 {
+		return Hint{Action: "draw_stockpile", Reason: "no discard to evaluate yet"}
+	}
+	top := g.DiscardPile[len(g.DiscardPile)-1]
+	if top.Value() <= 4 {
+		return Hint{Action: "draw_discard", Reason: "low point value, safe to take"}
+	}
+	return Hint{Action: "draw_stockpile", Reason: "top discard is high value, risky to take"}
+}
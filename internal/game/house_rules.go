@@ -0,0 +1,148 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// DeckConfig controls deck composition for a lobby, letting hosts tune the
+// game away from the standard deck (e.g. removing jokers, or running
+// multiple decks for a large table).
+type DeckConfig struct {
+	// NumJokers is how many jokers to include, 0-4.
+	NumJokers int
+	// RedKingsNegative matches standard Cambia scoring (red kings score
+	// -1); false scores them as a normal king (13).
+	RedKingsNegative bool
+	// NumDecks is how many standard 52-card decks to shuffle together,
+	// for player counts too large for a single deck to deal comfortably.
+	NumDecks int
+	// RankValues overrides the point value for specific ranks. A rank
+	// absent from the map keeps its standard value. Nil means no
+	// overrides.
+	RankValues map[models.Rank]int
+}
+
+// DefaultDeckConfig returns standard Cambia deck composition: one deck,
+// two jokers, red kings worth -1.
+func DefaultDeckConfig() DeckConfig {
+	return DeckConfig{NumJokers: 2, RedKingsNegative: true, NumDecks: 1}
+}
+
+// maxJokers bounds NumJokers: a standard box ships 2 jokers, so this allows
+// up to two boxes' worth. The bound is fixed; it doesn't scale with
+// NumDecks.
+const maxJokers = 4
+
+// Validate rejects deck configurations that can't be dealt, so a bad
+// lobby setting fails fast at configuration time rather than surfacing as
+// a confusing failure mid-deal.
+func (c DeckConfig) Validate() error {
+	if c.NumJokers < 0 || c.NumJokers > maxJokers {
+		return fmt.Errorf("num_jokers must be between 0 and %d, got %d", maxJokers, c.NumJokers)
+	}
+	if c.NumDecks < 1 {
+		return fmt.Errorf("num_decks must be at least 1, got %d", c.NumDecks)
+	}
+	return nil
+}
+
+// CardValue returns card's point value under this config: a RankValues
+// override if present, otherwise standard Cambia scoring with
+// RedKingsNegative applied.
+func (c DeckConfig) CardValue(card models.Card) int {
+	if c.RankValues != nil {
+		if v, ok := c.RankValues[card.Rank]; ok {
+			return v
+		}
+	}
+	if card.Rank == models.RankKing && !c.RedKingsNegative {
+		return 13
+	}
+	return card.Value()
+}
+
+// BuildDeck returns a deck matching c: c.NumDecks standard 52-card decks
+// shuffled together (undealt; callers shuffle), plus c.NumJokers jokers
+// total.
+func BuildDeck(c DeckConfig, newID func() string) ([]models.Card, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	suits := []models.Suit{models.SuitSpades, models.SuitHearts, models.SuitDiamonds, models.SuitClubs}
+	ranks := []models.Rank{
+		models.RankAce, models.RankTwo, models.RankThree, models.RankFour, models.RankFive,
+		models.RankSix, models.RankSeven, models.RankEight, models.RankNine, models.RankTen,
+		models.RankJack, models.RankQueen, models.RankKing,
+	}
+
+	deck := make([]models.Card, 0, c.NumDecks*52+c.NumJokers)
+	for d := 0; d < c.NumDecks; d++ {
+		for _, s := range suits {
+			for _, r := range ranks {
+				deck = append(deck, models.Card{ID: newID(), Rank: r, Suit: s})
+			}
+		}
+	}
+	for i := 0; i < c.NumJokers; i++ {
+		deck = append(deck, models.Card{ID: newID(), Rank: models.RankJoker, Suit: models.SuitJoker})
+	}
+	return deck, nil
+}
+
+// HouseRules bundles the lobby-configurable rules that affect how a game
+// is set up and scored, as opposed to TimeoutPolicy and Mode, which are
+// passed separately since they apply uniformly regardless of house rules.
+type HouseRules struct {
+	Deck DeckConfig
+	// FreezeForfeitedHand, if true, keeps a forfeited player's hand (e.g.
+	// after a vote-kick) out of the stockpile/discard flow instead of
+	// folding it back in, at the cost of shrinking the effective deck for
+	// the rest of the round.
+	FreezeForfeitedHand bool
+	// MaxTurns caps the game at this many total turns (summed across all
+	// players, i.e. Game.TurnCount), 0 meaning no cap. Protects against
+	// griefers who stall rather than ever calling cambia.
+	MaxTurns int
+	// MaxDuration caps the game at this much wall-clock time since
+	// CreatedAt, zero meaning no cap.
+	MaxDuration time.Duration
+	// TimeBank configures chess-style per-player time banks as an
+	// alternative to the fixed-duration Timer. Zero value (Reserve 0)
+	// disables time banks entirely.
+	TimeBank TimeBankConfig
+	// SnapGrace configures the RTT-compensated grace window applied when
+	// judging snap timing; see SnapGraceConfig.
+	SnapGrace SnapGraceConfig
+	// ResignPenalty is the fixed score substituted for a forfeited
+	// player's hand (see Game.Resign/ForfeitPlayer) when final scores are
+	// tallied. Nil excludes them from scoring entirely instead.
+	ResignPenalty *int
+	// MaxPlayers caps how many seats a lobby will fill before further
+	// joiners are waitlisted instead (see lobby.Lobby.AddMember), 0
+	// meaning no cap. There's no separate per-game seat limit: a game is
+	// seeded directly from its lobby's already-capped Members, so this is
+	// the only enforcement point.
+	MaxPlayers int
+	// DisconnectGraceSec, if positive, freezes a disconnecting player's
+	// seat (turn auto-skipped) for this many seconds instead of forfeiting
+	// them immediately, mirroring circuit mode's FreezeUserOnDisconnect but
+	// available outside circuit play. Zero forfeits on disconnect as
+	// before, subject to CambiaCallerProtected either way. See
+	// Game.OnDisconnect and Game.DisconnectGraceExpired.
+	DisconnectGraceSec int
+	// SnapPenalty configures the escalating draw count applied to repeated
+	// failed snap attempts within the same game; see
+	// Game.RecordSnapOutcome. Zero value (MaxDraws 0) disables it, meaning
+	// a failed snap has no consequence beyond the snap_attempt telemetry
+	// already recorded, as before this field existed.
+	SnapPenalty SnapPenaltyConfig
+}
+
+// DefaultHouseRules returns standard Cambia rules.
+func DefaultHouseRules() HouseRules {
+	return HouseRules{Deck: DefaultDeckConfig(), SnapGrace: DefaultSnapGraceConfig()}
+}
@@ -0,0 +1,100 @@
+package game
+
+import "github.com/jason-s-yu/cambia-service/internal/models"
+
+// Knowledge records which cards a player has legitimately seen the
+// identity of, e.g. via the initial peek, a peek action, or a penalty
+// reveal. It's keyed separately per player because the whole point is that
+// players' knowledge differs — one player's peek tells them nothing about
+// what another player has seen.
+//
+// It lives on Game rather than models.Player because it's server-only
+// bookkeeping: it's never serialized to other players, only to the player
+// it belongs to, via PrivateKnowledge.
+type Knowledge map[string]map[string]models.Card // userID -> cardID -> known card
+
+// RecordKnowledge records that userID has seen card's identity. Calling it
+// again for the same card just refreshes the record; it doesn't need to
+// track how many times or why.
+func (g *Game) RecordKnowledge(userID string, card models.Card) {
+	if g.Knowledge == nil {
+		g.Knowledge = make(Knowledge)
+	}
+	if g.Knowledge[userID] == nil {
+		g.Knowledge[userID] = make(map[string]models.Card)
+	}
+	g.Knowledge[userID][card.ID] = card
+}
+
+// ForgetKnowledge drops userID's knowledge of a card, e.g. once it's been
+// shuffled back into the stockpile and any prior knowledge of its position
+// is no longer valid.
+func (g *Game) ForgetKnowledge(userID, cardID string) {
+	delete(g.Knowledge[userID], cardID)
+}
+
+// PrivateKnowledge returns the cards userID currently knows the identity
+// of, keyed by card ID. The returned map is owned by the caller; it's a
+// copy so callers can serialize it without holding g.Mu.
+func (g *Game) PrivateKnowledge(userID string) map[string]models.Card {
+	out := make(map[string]models.Card, len(g.Knowledge[userID]))
+	for id, card := range g.Knowledge[userID] {
+		out[id] = card
+	}
+	return out
+}
+
+// SlotCardID returns the card ID currently occupying userID's slot, or
+// ok=false if userID isn't in the game, the slot is out of range, or the
+// slot is empty.
+func (g *Game) SlotCardID(userID string, slot int) (cardID string, ok bool) {
+	for _, p := range g.Players {
+		if p.UserID != userID {
+			continue
+		}
+		if slot < 0 || slot >= len(p.Slots) {
+			return "", false
+		}
+		cardID = p.Slots[slot]
+		return cardID, cardID != ""
+	}
+	return "", false
+}
+
+// VerifyKnownSlots checks a client-side memory-aid feature's claim that it
+// knows the identity of certain slots in userID's own hand, against
+// userID's actual Knowledge (built from real peek/deal history elsewhere
+// in this package). It returns the claimed slots that are NOT actually
+// known, so the client can clear its local "known" markers for them
+// rather than keep showing a belief the server can't back up — the whole
+// point being that a client-assist feature built on anything less than
+// verified server state risks becoming an information leak (e.g. if a
+// client inferred a slot's identity from timing/animation rather than a
+// real peek). Slots out of range or currently empty are silently skipped,
+// since a stale slot index isn't a correction worth reporting.
+func (g *Game) VerifyKnownSlots(userID string, claimedSlots []int) (corrected []int) {
+	var player *models.Player
+	for _, p := range g.Players {
+		if p.UserID == userID {
+			player = p
+			break
+		}
+	}
+	if player == nil {
+		return nil
+	}
+
+	for _, slot := range claimedSlots {
+		if slot < 0 || slot >= len(player.Slots) {
+			continue
+		}
+		cardID := player.Slots[slot]
+		if cardID == "" {
+			continue
+		}
+		if _, known := g.Knowledge[userID][cardID]; !known {
+			corrected = append(corrected, slot)
+		}
+	}
+	return corrected
+}
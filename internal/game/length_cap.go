@@ -0,0 +1,33 @@
+package game
+
+import "time"
+
+// LengthCapReached reports whether g has hit either HouseRules.MaxTurns or
+// HouseRules.MaxDuration, the two independent ways a game can be capped.
+// A zero value for either disables that particular cap.
+func (g *Game) LengthCapReached() bool {
+	if g.HouseRules.MaxTurns > 0 && g.TurnCount >= g.HouseRules.MaxTurns {
+		return true
+	}
+	if g.HouseRules.MaxDuration > 0 && time.Since(g.CreatedAt) >= g.HouseRules.MaxDuration {
+		return true
+	}
+	return false
+}
+
+// EnforceLengthCap ends g if it has hit its configured length cap: ranked
+// games are voided, since a capped ranked game has no fair winner, while
+// every other mode is completed with whatever scores stand. It reports
+// whether it actually ended the game, so callers only emit the
+// length-cap-reached event once.
+func (g *Game) EnforceLengthCap() bool {
+	if g.Status.Terminal() || !g.LengthCapReached() {
+		return false
+	}
+	if g.Mode == ModeRanked {
+		g.Status = StatusVoided
+	} else {
+		g.Status = StatusCompleted
+	}
+	return true
+}
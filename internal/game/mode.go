@@ -0,0 +1,38 @@
+package game
+
+// Mode names a ruleset/context a game is played under. Modes share the
+// core engine but hook into it differently (circuit mode freezes seats,
+// ranked mode records rating changes, etc.).
+type Mode string
+
+const (
+	ModeCasual   Mode = "casual"
+	ModeRanked   Mode = "ranked"
+	ModeCircuit  Mode = "circuit"
+	ModePractice Mode = "practice"
+)
+
+// Hooks are the per-mode callbacks the engine invokes at key lifecycle
+// points. Modes that don't care about a given point leave it nil; the
+// engine treats a nil hook as a no-op.
+type Hooks struct {
+	OnGameStarted func(g *Game)
+	OnGameEnded   func(g *Game)
+	OnDisconnect  func(g *Game, userID string)
+}
+
+// defaultHooksByMode wires each Mode to its Hooks. Casual has no special
+// behavior beyond the base engine, so it's omitted (zero-value Hooks).
+var defaultHooksByMode = map[Mode]Hooks{
+	ModeCircuit: {
+		OnDisconnect: func(g *Game, userID string) {
+			g.OnDisconnect(userID)
+		},
+	},
+}
+
+// HooksFor returns the Hooks registered for mode, or a zero-value Hooks if
+// the mode has none.
+func HooksFor(mode Mode) Hooks {
+	return defaultHooksByMode[mode]
+}
@@ -0,0 +1,54 @@
+package game
+
+import "github.com/jason-s-yu/cambia-service/internal/models"
+
+// PublicPlayer is one player's publicly visible state: hand contents are
+// deliberately omitted, since a player's cards are never public
+// knowledge while they're still in hand.
+type PublicPlayer struct {
+	UserID      string `json:"user_id"`
+	DisplayName string `json:"display_name,omitempty"`
+	HandSize    int    `json:"hand_size"`
+	Connected   bool   `json:"connected"`
+}
+
+// PublicState is the subset of Game visible to an unauthenticated
+// spectator with no special knowledge: everyone's hand size (not
+// contents), the discard pile's top card (public the moment it's
+// discarded), the stockpile's remaining count (not contents), and
+// lifecycle/turn info.
+type PublicState struct {
+	GameID       string         `json:"game_id"`
+	Status       Status         `json:"status"`
+	TurnUserID   string         `json:"turn_user_id,omitempty"`
+	StockpileLen int            `json:"stockpile_len"`
+	DiscardTop   *models.Card   `json:"discard_top,omitempty"`
+	Players      []PublicPlayer `json:"players"`
+}
+
+// PublicState builds g's obfuscated, spectator-safe view. Callers must
+// hold g.Mu while calling it, the same as any other read of Game fields.
+func (g *Game) PublicState() PublicState {
+	ps := PublicState{
+		GameID:       g.ID,
+		Status:       g.Status,
+		StockpileLen: len(g.Stockpile),
+		Players:      make([]PublicPlayer, 0, len(g.Players)),
+	}
+	if len(g.DiscardPile) > 0 {
+		top := g.DiscardPile[len(g.DiscardPile)-1]
+		ps.DiscardTop = &top
+	}
+	if len(g.Players) > 0 && !g.Status.Terminal() {
+		ps.TurnUserID = g.Players[g.TurnIdx%len(g.Players)].UserID
+	}
+	for _, p := range g.Players {
+		ps.Players = append(ps.Players, PublicPlayer{
+			UserID:      p.UserID,
+			DisplayName: p.DisplayName,
+			HandSize:    len(p.Hand),
+			Connected:   p.Connected,
+		})
+	}
+	return ps
+}
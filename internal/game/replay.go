@@ -0,0 +1,40 @@
+package game
+
+import "github.com/jason-s-yu/cambia-service/internal/historian"
+
+// ReplayObfuscation controls how much of a game's history is revealed when
+// assembling a replay. Replays default to hiding information a live
+// spectator wouldn't have had, so a leaked replay URL can't be used to
+// retroactively card-count a finished game.
+type ReplayObfuscation string
+
+const (
+	// ReplayFull reveals every recorded event verbatim, including
+	// card_knowledge payloads. Intended for the players who were in the
+	// game, or for moderation review of a dispute.
+	ReplayFull ReplayObfuscation = "full"
+	// ReplayPublic redacts card_knowledge payloads, since those reveal a
+	// specific player's private information rather than public game state.
+	ReplayPublic ReplayObfuscation = "public"
+)
+
+// AssembleReplay reconstructs an ordered, obfuscation-configurable replay
+// stream from a historian log. It doesn't re-simulate game state: the
+// historian log already records what happened, in order, so replay is a
+// filter over that log rather than a reconstruction from action payloads.
+func AssembleReplay(events []historian.GameEvent, mode ReplayObfuscation) []historian.GameEvent {
+	if mode == ReplayFull {
+		out := make([]historian.GameEvent, len(events))
+		copy(out, events)
+		return out
+	}
+
+	out := make([]historian.GameEvent, 0, len(events))
+	for _, e := range events {
+		if e.Type == historian.EventCardKnowledge {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
@@ -0,0 +1,12 @@
+package game
+
+// Resign voluntarily forfeits userID, distinct from a disconnect-driven
+// forfeit (ShouldForfeitOnDisconnect) or a vote-kick (ResolveVoteKick):
+// it's always the player's own choice, and takes effect immediately
+// regardless of those other paths' configuration. It shares
+// ForfeitPlayer's hand-freeze/fold and turn-order removal behavior, since
+// "removed from play voluntarily" and "removed from play by a vote" need
+// the same bookkeeping either way.
+func (g *Game) Resign(userID string) {
+	g.ForfeitPlayer(userID)
+}
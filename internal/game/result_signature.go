@@ -0,0 +1,88 @@
+package game
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+)
+
+// SignedResult is a finished game's outcome together with a signature over
+// it, so a third-party league site consuming scores/winner can verify they
+// came from this server and weren't tampered with client-side.
+type SignedResult struct {
+	GameID string         `json:"game_id"`
+	Winner string         `json:"winner"`
+	Scores map[string]int `json:"scores"`
+	// SeedHash is a SHA-256 hash of the deck shuffle seed, not the seed
+	// itself: it lets a dispute be tied to a specific seed without handing
+	// out a value a suspicious player could otherwise use to predict a
+	// future deal from the same process.
+	SeedHash string `json:"seed_hash"`
+	// Signature is base64-encoded ed25519, computed over every other field
+	// via signingPayload. It's excluded from signingPayload itself, since a
+	// field can't sign over its own value.
+	Signature string `json:"signature,omitempty"`
+}
+
+// signingPayload returns the canonical bytes a ResultSigner signs and
+// Verify checks against. encoding/json sorts map keys when marshaling a
+// map[string]int, so this is deterministic regardless of Scores'
+// iteration order.
+func (r SignedResult) signingPayload() []byte {
+	r.Signature = ""
+	b, _ := json.Marshal(r)
+	return b
+}
+
+// ResultSigner signs finished games' outcomes with an ed25519 private key.
+// The same shape as lobby.VoiceBroker's signingKey, swapped to ed25519
+// rather than HMAC since a results signature needs to be checkable by
+// external league sites that never hold the server's secret — only the
+// corresponding public key.
+type ResultSigner struct {
+	key ed25519.PrivateKey
+}
+
+// NewResultSigner returns a signer using key.
+func NewResultSigner(key ed25519.PrivateKey) *ResultSigner {
+	return &ResultSigner{key: key}
+}
+
+// PublicKey returns the public key third parties need to call Verify,
+// typically published once alongside the results API rather than per
+// result.
+func (s *ResultSigner) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// Sign builds and signs gameID's outcome from scores and the deck shuffle
+// seed recorded in Game.Audit.RNGSeed.
+func (s *ResultSigner) Sign(gameID string, seed int64, scores map[string]int) SignedResult {
+	winner, _ := Winner(scores)
+	res := SignedResult{
+		GameID:   gameID,
+		Winner:   winner,
+		Scores:   scores,
+		SeedHash: hashSeed(seed),
+	}
+	res.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, res.signingPayload()))
+	return res
+}
+
+// Verify reports whether res.Signature is a valid ed25519 signature over
+// res's other fields under pub.
+func Verify(pub ed25519.PublicKey, res SignedResult) bool {
+	sig, err := base64.StdEncoding.DecodeString(res.Signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(pub, res.signingPayload(), sig)
+}
+
+func hashSeed(seed int64) string {
+	sum := sha256.Sum256([]byte(strconv.FormatInt(seed, 10)))
+	return hex.EncodeToString(sum[:])
+}
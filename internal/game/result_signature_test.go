@@ -0,0 +1,79 @@
+package game
+
+import (
+	"crypto/ed25519"
+	"testing"
+)
+
+// TestResultSignerRoundTrip verifies that Sign produces a SignedResult whose
+// Signature Verify accepts under the signer's own public key.
+func TestResultSignerRoundTrip(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	signer := NewResultSigner(priv)
+	scores := map[string]int{"alice": 4, "bob": 9}
+
+	res := signer.Sign("game-1", 42, scores)
+	if res.Signature == "" {
+		t.Fatal("Sign left Signature empty")
+	}
+	if !Verify(signer.PublicKey(), res) {
+		t.Fatal("Verify rejected a signature from Sign's own output")
+	}
+}
+
+// TestResultSignerVerifyRejectsTampering is a property test: mutating any
+// single field of a signed result after the fact must make Verify reject
+// it, since a league site trusting this signature has to be able to detect
+// a tampered score or winner.
+func TestResultSignerVerifyRejectsTampering(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	signer := NewResultSigner(priv)
+	pub := signer.PublicKey()
+	base := signer.Sign("game-1", 42, map[string]int{"alice": 4, "bob": 9})
+
+	cases := []struct {
+		name   string
+		tamper func(r SignedResult) SignedResult
+	}{
+		{"game id", func(r SignedResult) SignedResult { r.GameID = "game-2"; return r }},
+		{"winner", func(r SignedResult) SignedResult { r.Winner = "bob"; return r }},
+		{"scores", func(r SignedResult) SignedResult {
+			r.Scores = map[string]int{"alice": 4, "bob": 99}
+			return r
+		}},
+		{"seed hash", func(r SignedResult) SignedResult { r.SeedHash = "deadbeef"; return r }},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tampered := c.tamper(base)
+			if Verify(pub, tampered) {
+				t.Fatalf("Verify accepted a result with %s tampered", c.name)
+			}
+		})
+	}
+}
+
+// TestResultSignerVerifyRejectsWrongKey verifies that a signature only
+// verifies under the signer's own public key, not an unrelated one.
+func TestResultSignerVerifyRejectsWrongKey(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	signer := NewResultSigner(priv)
+	res := signer.Sign("game-1", 42, map[string]int{"alice": 4, "bob": 9})
+
+	if Verify(otherPub, res) {
+		t.Fatal("Verify accepted a signature under an unrelated public key")
+	}
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// HandScore sums the point values of a set of cards (a player's remaining
+// hand at round end) under standard scoring. Lower is better in Cambia.
+func HandScore(cards []models.Card) int {
+	total := 0
+	for _, c := range cards {
+		total += c.Value()
+	}
+	return total
+}
+
+// HandScoreWithConfig is HandScore under a lobby's DeckConfig, respecting
+// RedKingsNegative and any RankValues overrides.
+func HandScoreWithConfig(cards []models.Card, config DeckConfig) int {
+	total := 0
+	for _, c := range cards {
+		total += config.CardValue(c)
+	}
+	return total
+}
+
+// FinalScore returns userID's score for the final tally, honoring
+// HouseRules.ResignPenalty for a forfeited player (via Resign or a
+// vote-kick): rawScore if they weren't forfeited, the configured penalty
+// if they were and one's set, or excluded (ok=false) if they were
+// forfeited and ResignPenalty is nil.
+func (g *Game) FinalScore(userID string, rawScore int) (score int, ok bool) {
+	if !g.Forfeited[userID] {
+		return rawScore, true
+	}
+	if g.HouseRules.ResignPenalty == nil {
+		return 0, false
+	}
+	return *g.HouseRules.ResignPenalty, true
+}
+
+// Winner returns the userID of whoever has the lowest HandScore among
+// scores, and false if scores is empty. Ties are broken by lowest userID
+// (lexical sort), not by map iteration order: Winner is called
+// independently from both ResultSigner.Sign and the stats handlers, and
+// those calls need to agree on a tied outcome, which plain map iteration
+// can't guarantee since Go randomizes it per iteration.
+func Winner(scores map[string]int) (userID string, ok bool) {
+	if len(scores) == 0 {
+		return "", false
+	}
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	best := ids[0]
+	for _, id := range ids[1:] {
+		if scores[id] < scores[best] {
+			best = id
+		}
+	}
+	return best, true
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// TestHandScoreNonNegativeForNonJokerHands is a property test: any hand
+// made up of numbered cards (2-10) should never score negative, since only
+// jokers and red kings can. quick.Check exercises this across many
+// randomly generated hand sizes.
+func TestHandScoreNonNegativeForNonJokerHands(t *testing.T) {
+	f := func(n uint8) bool {
+		count := int(n % 20)
+		cards := make([]models.Card, count)
+		for i := range cards {
+			cards[i] = models.Card{Rank: models.RankFive, Suit: models.SuitClubs}
+		}
+		return HandScore(cards) >= 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestWinnerAlwaysHasMinimalScore is a property test: whoever Winner picks
+// must have a score no higher than anyone else's, for any non-empty score
+// map.
+func TestWinnerAlwaysHasMinimalScore(t *testing.T) {
+	f := func(scores map[string]int8) bool {
+		if len(scores) == 0 {
+			return true
+		}
+		wide := make(map[string]int, len(scores))
+		for k, v := range scores {
+			wide[k] = int(v)
+		}
+		winner, ok := Winner(wide)
+		if !ok {
+			return false
+		}
+		for _, score := range wide {
+			if score < wide[winner] {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestWinnerStableOnTies is a property test: calling Winner repeatedly on
+// an equivalent tied scores map must always return the same userID, since
+// ResultSigner.Sign and the stats handlers both call Winner independently
+// and need to agree.
+func TestWinnerStableOnTies(t *testing.T) {
+	f := func(scores map[string]int8) bool {
+		if len(scores) == 0 {
+			return true
+		}
+		wide := make(map[string]int, len(scores))
+		for k, v := range scores {
+			wide[k] = int(v)
+		}
+		first, ok := Winner(wide)
+		if !ok {
+			return false
+		}
+		for i := 0; i < 10; i++ {
+			got, _ := Winner(wide)
+			if got != first {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,45 @@
+package game
+
+import "time"
+
+// SnapGraceConfig configures a small server-side grace window applied to
+// snap timing judgments, relative to the snapper's own measured
+// round-trip time, so a snap that's "late" only because of an average
+// mobile-network RTT isn't judged the same as one that's genuinely slow.
+type SnapGraceConfig struct {
+	// MaxGrace bounds the grace window regardless of RTT, so a snapper on
+	// a terrible connection can't claim an unbounded allowance.
+	MaxGrace time.Duration
+	// RTTFraction is the portion of measured RTT granted as grace, e.g.
+	// 0.5 grants half of RTT, capped by MaxGrace.
+	RTTFraction float64
+}
+
+// DefaultSnapGraceConfig returns a conservative default: half of measured
+// RTT, capped at 150ms.
+func DefaultSnapGraceConfig() SnapGraceConfig {
+	return SnapGraceConfig{MaxGrace: 150 * time.Millisecond, RTTFraction: 0.5}
+}
+
+// Grace returns the grace window granted for a snapper with the given
+// measured RTT.
+func (c SnapGraceConfig) Grace(rtt time.Duration) time.Duration {
+	if c.RTTFraction <= 0 || rtt <= 0 {
+		return 0
+	}
+	grace := time.Duration(float64(rtt) * c.RTTFraction)
+	if c.MaxGrace > 0 && grace > c.MaxGrace {
+		return c.MaxGrace
+	}
+	return grace
+}
+
+// WithinGrace reports whether a snap that arrived overBy after its
+// deadline should still count as in time, given the snapper's measured
+// RTT. overBy <= 0 is always within grace (it wasn't late at all).
+func (c SnapGraceConfig) WithinGrace(overBy, rtt time.Duration) bool {
+	if overBy <= 0 {
+		return true
+	}
+	return overBy <= c.Grace(rtt)
+}
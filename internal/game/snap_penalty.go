@@ -0,0 +1,98 @@
+package game
+
+import "github.com/jason-s-yu/cambia-service/internal/models"
+
+// SnapPenaltyConfig bounds the escalating draw penalty applied to repeated
+// failed snap attempts within the same game. Zero value (MaxDraws 0)
+// disables escalation entirely, the same "zero disables" convention as
+// SnapGraceConfig and HouseRules.MaxTurns.
+type SnapPenaltyConfig struct {
+	// BaseDraws is the draw count for a player's first failed snap.
+	BaseDraws int
+	// DrawIncrement is added per additional consecutive failed snap.
+	DrawIncrement int
+	// MaxDraws caps the escalation regardless of how many consecutive
+	// snaps a player has failed. 0 disables the penalty outright.
+	MaxDraws int
+}
+
+// DefaultSnapPenaltyConfig returns the escalation named in its originating
+// request: 2 draws on the first failed snap, then 3, then 4, capped there.
+func DefaultSnapPenaltyConfig() SnapPenaltyConfig {
+	return SnapPenaltyConfig{BaseDraws: 2, DrawIncrement: 1, MaxDraws: 4}
+}
+
+// drawsFor returns the draw count for a player's count-th consecutive
+// failed snap this game (count is 1-indexed: the first failure is count 1).
+func (c SnapPenaltyConfig) drawsFor(count int) int {
+	if c.MaxDraws <= 0 || count <= 0 {
+		return 0
+	}
+	draws := c.BaseDraws + c.DrawIncrement*(count-1)
+	if draws > c.MaxDraws {
+		draws = c.MaxDraws
+	}
+	return draws
+}
+
+// RecordSnapOutcome updates userID's consecutive-failed-snap count for
+// HouseRules.SnapPenalty (a success resets it to 0) and, on a failure with
+// the penalty enabled, draws the escalated card count into their hand. It
+// returns the cards drawn, nil if the penalty is disabled, the stockpile
+// and discard pile are both exhausted, or won is true.
+//
+// Per-round reset in circuit mode falls out for free: each circuit round
+// plays out in a freshly constructed Game (see ws.RunCircuitScheduler's
+// startNextRound), so FailedSnaps always starts at zero for a new round
+// without this needing its own reset path.
+func (g *Game) RecordSnapOutcome(userID string, won bool) []models.Card {
+	if won {
+		delete(g.FailedSnaps, userID)
+		return nil
+	}
+	if g.FailedSnaps == nil {
+		g.FailedSnaps = make(map[string]int)
+	}
+	g.FailedSnaps[userID]++
+	draws := g.HouseRules.SnapPenalty.drawsFor(g.FailedSnaps[userID])
+	if draws == 0 {
+		return nil
+	}
+	return g.drawPenaltyCards(userID, draws)
+}
+
+// drawPenaltyCards moves up to n cards from the stockpile (reshuffling the
+// discard pile in if it runs dry) into userID's hand, returning whatever
+// was actually drawn if the deck can't fully satisfy n.
+func (g *Game) drawPenaltyCards(userID string, n int) []models.Card {
+	var player *models.Player
+	for _, p := range g.Players {
+		if p.UserID == userID {
+			player = p
+			break
+		}
+	}
+	if player == nil {
+		return nil
+	}
+
+	drawn := make([]models.Card, 0, n)
+	for i := 0; i < n; i++ {
+		if len(g.Stockpile) == 0 {
+			if len(g.DiscardPile) <= 1 {
+				break
+			}
+			g.ReshuffleDiscardIntoStockpile(nil)
+			if len(g.Stockpile) == 0 {
+				break
+			}
+		}
+		card := g.Stockpile[len(g.Stockpile)-1]
+		g.Stockpile = g.Stockpile[:len(g.Stockpile)-1]
+		g.Cards[card.ID] = card
+		player.Hand = append(player.Hand, card.ID)
+		player.AssignSlot(card.ID)
+		drawn = append(drawn, card)
+	}
+	return drawn
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/circuit"
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// Snapshot is the serializable subset of Game state, used to persist an
+// in-progress game across a server restart. It excludes Mu (not
+// serializable), Hooks (rebuilt from Mode via HooksFor), the unexported
+// cardAliasKey, and Dormant, FailedSnaps, RatingsFinalized and
+// SignedResult. Timer is kept, so Restore preserves its StartedAt.
+type Snapshot struct {
+	ID            string
+	Status        Status
+	Players       []*models.Player
+	TurnIdx       int
+	TurnCount     int
+	Stockpile     []models.Card
+	DiscardPile   []models.Card
+	Cards         map[string]models.Card
+	CreatedAt     time.Time
+	Timer         *TurnTimer
+	TimeoutPolicy TimeoutPolicy
+	NudgeCounts   map[string]int
+	CircuitRules  *circuit.Rules
+	Seats         map[string]*circuit.Seat
+	AbortVote     *AbortVote
+	Cambia        *CambiaCall
+	Mode          Mode
+	Dispute       *Dispute
+	Knowledge     Knowledge
+	HouseRules    HouseRules
+	Forfeited     map[string]bool
+	VoteKick      *VoteKick
+	Audit         Audit
+	TimeBanks     map[string]*TimeBank
+}
+
+// Snapshot captures g's current state for persistence. Callers must hold
+// g.Mu while calling it, the same as any other read of Game fields.
+func (g *Game) Snapshot() Snapshot {
+	return Snapshot{
+		ID:            g.ID,
+		Status:        g.Status,
+		Players:       g.Players,
+		TurnIdx:       g.TurnIdx,
+		TurnCount:     g.TurnCount,
+		Stockpile:     g.Stockpile,
+		DiscardPile:   g.DiscardPile,
+		Cards:         g.Cards,
+		CreatedAt:     g.CreatedAt,
+		Timer:         g.Timer,
+		TimeoutPolicy: g.TimeoutPolicy,
+		NudgeCounts:   g.NudgeCounts,
+		CircuitRules:  g.CircuitRules,
+		Seats:         g.Seats,
+		AbortVote:     g.AbortVote,
+		Cambia:        g.Cambia,
+		Mode:          g.Mode,
+		Dispute:       g.Dispute,
+		Knowledge:     g.Knowledge,
+		HouseRules:    g.HouseRules,
+		Forfeited:     g.Forfeited,
+		VoteKick:      g.VoteKick,
+		Audit:         g.Audit,
+		TimeBanks:     g.TimeBanks,
+	}
+}
+
+// MarshalSnapshot serializes g's current state to JSON for persistence.
+func (g *Game) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(g.Snapshot())
+}
+
+// Restore rebuilds a live Game from a persisted Snapshot. The restored
+// Timer's StartedAt is preserved from the snapshot, so Remaining() reports
+// time as if the process had never stopped, rather than granting a fresh
+// full turn for free on every crash.
+func Restore(snap Snapshot) *Game {
+	return &Game{
+		ID:            snap.ID,
+		Status:        snap.Status,
+		Players:       snap.Players,
+		TurnIdx:       snap.TurnIdx,
+		TurnCount:     snap.TurnCount,
+		Stockpile:     snap.Stockpile,
+		DiscardPile:   snap.DiscardPile,
+		Cards:         snap.Cards,
+		CreatedAt:     snap.CreatedAt,
+		Timer:         snap.Timer,
+		TimeoutPolicy: snap.TimeoutPolicy,
+		NudgeCounts:   snap.NudgeCounts,
+		CircuitRules:  snap.CircuitRules,
+		Seats:         snap.Seats,
+		AbortVote:     snap.AbortVote,
+		Cambia:        snap.Cambia,
+		Mode:          snap.Mode,
+		Hooks:         HooksFor(snap.Mode),
+		Dispute:       snap.Dispute,
+		Knowledge:     snap.Knowledge,
+		HouseRules:    snap.HouseRules,
+		Forfeited:     snap.Forfeited,
+		VoteKick:      snap.VoteKick,
+		Audit:         snap.Audit,
+		TimeBanks:     snap.TimeBanks,
+	}
+}
+
+// UnmarshalSnapshot restores a Game from its persisted JSON form.
+func UnmarshalSnapshot(data []byte) (*Game, error) {
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+	return Restore(snap), nil
+}
@@ -0,0 +1,29 @@
+package game
+
+import "github.com/jason-s-yu/cambia-service/internal/models"
+
+// StockpileExhausted reports whether the stockpile has run dry with no
+// discard pile to reshuffle from, meaning the round must end immediately
+// rather than waiting for a Cambia call.
+func (g *Game) StockpileExhausted() bool {
+	return len(g.Stockpile) == 0 && len(g.DiscardPile) <= 1
+}
+
+// ReshuffleDiscardIntoStockpile recycles the discard pile (minus its top
+// card, which stays visible) into a fresh stockpile when the stockpile runs
+// dry but the discard pile still has cards to draw from. shuffle is applied
+// to the new stockpile in place.
+func (g *Game) ReshuffleDiscardIntoStockpile(shuffle func([]models.Card)) {
+	if len(g.DiscardPile) <= 1 {
+		return
+	}
+	reclaimed := g.DiscardPile[:len(g.DiscardPile)-1]
+	top := g.DiscardPile[len(g.DiscardPile)-1]
+
+	g.Stockpile = append(g.Stockpile, reclaimed...)
+	if shuffle != nil {
+		shuffle(g.Stockpile)
+	}
+	g.DiscardPile = []models.Card{top}
+	g.Audit.ReshuffleCount++
+}
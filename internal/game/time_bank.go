@@ -0,0 +1,100 @@
+package game
+
+import "time"
+
+// TimeBankConfig configures chess-style time banks: each player starts a
+// game with Reserve of total thinking time and gets PerTurnIncrement back
+// each time they end their turn, rather than a fixed per-turn budget that
+// resets every turn regardless of how much time was actually used.
+// Reserve of 0 means time banks are disabled for the game.
+type TimeBankConfig struct {
+	Reserve          time.Duration
+	PerTurnIncrement time.Duration
+}
+
+// DefaultTimeBankConfig returns a disabled TimeBankConfig (Reserve 0),
+// since time banks are an opt-in alternative to the simpler fixed-duration
+// TurnTimer most games use.
+func DefaultTimeBankConfig() TimeBankConfig {
+	return TimeBankConfig{}
+}
+
+// TimeBank tracks one player's remaining thinking time across a whole
+// game. TurnStartedAt and Running are exported, the same reason
+// TurnTimer.StartedAt is: so a restored Snapshot preserves an in-progress
+// turn's elapsed time rather than granting it back for free.
+type TimeBank struct {
+	Remaining     time.Duration
+	TurnStartedAt time.Time
+	Running       bool
+}
+
+// NewTimeBank returns a fresh TimeBank seeded from cfg.Reserve, not yet
+// running.
+func NewTimeBank(cfg TimeBankConfig) *TimeBank {
+	return &TimeBank{Remaining: cfg.Reserve}
+}
+
+// Start begins deducting from Remaining for the player whose turn it now
+// is. Calling Start while already running restarts the clock from now.
+func (b *TimeBank) Start() {
+	b.TurnStartedAt = time.Now()
+	b.Running = true
+}
+
+// Stop ends the current turn's deduction, folding the elapsed time into
+// Remaining and crediting increment back, the same way a chess clock adds
+// its per-move bonus when a player completes their move.
+func (b *TimeBank) Stop(increment time.Duration) {
+	if b.Running {
+		b.Remaining -= time.Since(b.TurnStartedAt)
+		b.Running = false
+	}
+	b.Remaining += increment
+}
+
+// RemainingNow reports the bank's remaining time as of this instant,
+// accounting for time elapsed on the current turn if Running.
+func (b *TimeBank) RemainingNow() time.Duration {
+	if !b.Running {
+		return b.Remaining
+	}
+	return b.Remaining - time.Since(b.TurnStartedAt)
+}
+
+// Expired reports whether the player has run out of thinking time.
+func (b *TimeBank) Expired() bool {
+	return b.RemainingNow() <= 0
+}
+
+// TimeBankFor returns userID's TimeBank, lazily creating one from
+// HouseRules.TimeBank on first use so joining mid-game still gets a bank.
+func (g *Game) TimeBankFor(userID string) *TimeBank {
+	if g.TimeBanks == nil {
+		g.TimeBanks = make(map[string]*TimeBank)
+	}
+	b, ok := g.TimeBanks[userID]
+	if !ok {
+		b = NewTimeBank(g.HouseRules.TimeBank)
+		g.TimeBanks[userID] = b
+	}
+	return b
+}
+
+// StartTimeBank starts userID's clock for their turn. A no-op when time
+// banks aren't configured for this game.
+func (g *Game) StartTimeBank(userID string) {
+	if g.HouseRules.TimeBank.Reserve <= 0 {
+		return
+	}
+	g.TimeBankFor(userID).Start()
+}
+
+// StopTimeBank ends userID's clock, crediting the configured per-turn
+// increment. A no-op when time banks aren't configured for this game.
+func (g *Game) StopTimeBank(userID string) {
+	if g.HouseRules.TimeBank.Reserve <= 0 {
+		return
+	}
+	g.TimeBankFor(userID).Stop(g.HouseRules.TimeBank.PerTurnIncrement)
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// TimeoutOutcome describes what ApplyTimeoutExpiry actually did, so the
+// caller (see ws.RunTurnExpiryScheduler) knows what to broadcast without
+// re-deriving it from TimeoutPolicy itself.
+type TimeoutOutcome struct {
+	Action TimeoutAction
+	// Discarded is the card that ended up on the discard pile, if any: the
+	// drawn card itself for TimeoutDrawStockpile (the draw immediately
+	// forfeits the decision by discarding it), a random hand card for
+	// TimeoutDiscardRandom, or nil for TimeoutSkip. Either way it's now
+	// public knowledge, same as any other discard.
+	Discarded *models.Card
+}
+
+// ApplyTimeoutExpiry enforces g.TimeoutPolicy.Action against userID, the
+// current player, after their turn timer has expired without them acting.
+// It does not advance the turn or reset the timer; callers do that
+// afterward the same way a normal action handler does (see
+// cmd/server/main.go's draw/discard dispatch).
+func (g *Game) ApplyTimeoutExpiry(userID string) (TimeoutOutcome, error) {
+	switch g.TimeoutPolicy.Action {
+	case TimeoutDrawStockpile:
+		drawn, err := g.DrawStockpile(userID)
+		if err != nil {
+			return TimeoutOutcome{}, err
+		}
+		discarded, err := g.Discard(userID, drawn.ID)
+		if err != nil {
+			return TimeoutOutcome{}, err
+		}
+		return TimeoutOutcome{Action: TimeoutDrawStockpile, Discarded: &discarded}, nil
+	case TimeoutDiscardRandom:
+		player := g.playerByID(userID)
+		if player == nil {
+			return TimeoutOutcome{}, ErrPlayerNotFound
+		}
+		if len(player.Hand) == 0 {
+			return TimeoutOutcome{Action: TimeoutDiscardRandom}, nil
+		}
+		cardID := player.Hand[randIndex(len(player.Hand))]
+		discarded, err := g.Discard(userID, cardID)
+		if err != nil {
+			return TimeoutOutcome{}, err
+		}
+		return TimeoutOutcome{Action: TimeoutDiscardRandom, Discarded: &discarded}, nil
+	default: // TimeoutSkip, or an unrecognized value defaults to a no-op skip
+		return TimeoutOutcome{Action: TimeoutSkip}, nil
+	}
+}
+
+// randIndex returns a uniform random index in [0, n) using crypto/rand,
+// matching this package's existing avoidance of math/rand (see
+// Game.cardAliasKey and lobby.Lobby's invite codes).
+func randIndex(n int) int {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0
+	}
+	return int(i.Int64())
+}
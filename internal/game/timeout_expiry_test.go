@@ -0,0 +1,96 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// TestApplyTimeoutExpiryDrawStockpileForfeitsDraw verifies the default
+// policy draws a card and immediately discards it, leaving the player's
+// hand unchanged but the card on the discard pile.
+func TestApplyTimeoutExpiryDrawStockpileForfeitsDraw(t *testing.T) {
+	g := NewGame("g1")
+	g.Status = StatusActive
+	g.TimeoutPolicy = TimeoutPolicy{Action: TimeoutDrawStockpile}
+	g.AddPlayer("u1")
+	top := models.Card{ID: "c1", Rank: models.RankFive, Suit: models.SuitClubs}
+	g.Stockpile = []models.Card{top}
+
+	outcome, err := g.ApplyTimeoutExpiry("u1")
+	if err != nil {
+		t.Fatalf("ApplyTimeoutExpiry: %v", err)
+	}
+	if outcome.Action != TimeoutDrawStockpile {
+		t.Fatalf("Action = %v, want TimeoutDrawStockpile", outcome.Action)
+	}
+	if outcome.Discarded == nil || outcome.Discarded.ID != top.ID {
+		t.Fatalf("Discarded = %v, want %s", outcome.Discarded, top.ID)
+	}
+	p := g.Players[0]
+	if len(p.Hand) != 0 {
+		t.Fatalf("hand = %v, want empty (drawn card forfeited)", p.Hand)
+	}
+	if len(g.DiscardPile) != 1 || g.DiscardPile[0].ID != top.ID {
+		t.Fatalf("discard pile = %v, want [%s]", g.DiscardPile, top.ID)
+	}
+}
+
+// TestApplyTimeoutExpiryDiscardRandomDropsHandCard verifies the harsher
+// ranked-mode policy discards a card already in hand without drawing.
+func TestApplyTimeoutExpiryDiscardRandomDropsHandCard(t *testing.T) {
+	g := NewGame("g1")
+	g.Status = StatusActive
+	g.TimeoutPolicy = TimeoutPolicy{Action: TimeoutDiscardRandom}
+	g.AddPlayer("u1")
+	card := models.Card{ID: "c1", Rank: models.RankSix, Suit: models.SuitDiamonds}
+	g.Cards[card.ID] = card
+	p := g.Players[0]
+	p.Hand = append(p.Hand, card.ID)
+	p.AssignSlot(card.ID)
+	stockpileBefore := len(g.Stockpile)
+
+	outcome, err := g.ApplyTimeoutExpiry("u1")
+	if err != nil {
+		t.Fatalf("ApplyTimeoutExpiry: %v", err)
+	}
+	if outcome.Action != TimeoutDiscardRandom {
+		t.Fatalf("Action = %v, want TimeoutDiscardRandom", outcome.Action)
+	}
+	if outcome.Discarded == nil || outcome.Discarded.ID != card.ID {
+		t.Fatalf("Discarded = %v, want %s", outcome.Discarded, card.ID)
+	}
+	if len(p.Hand) != 0 {
+		t.Fatalf("hand = %v, want empty", p.Hand)
+	}
+	if len(g.Stockpile) != stockpileBefore {
+		t.Fatalf("stockpile len = %d, want unchanged at %d", len(g.Stockpile), stockpileBefore)
+	}
+}
+
+// TestApplyTimeoutExpirySkipMovesNoCards verifies TimeoutSkip leaves the
+// hand and piles untouched.
+func TestApplyTimeoutExpirySkipMovesNoCards(t *testing.T) {
+	g := NewGame("g1")
+	g.Status = StatusActive
+	g.TimeoutPolicy = TimeoutPolicy{Action: TimeoutSkip}
+	g.AddPlayer("u1")
+	card := models.Card{ID: "c1", Rank: models.RankSeven, Suit: models.SuitSpades}
+	g.Cards[card.ID] = card
+	p := g.Players[0]
+	p.Hand = append(p.Hand, card.ID)
+
+	outcome, err := g.ApplyTimeoutExpiry("u1")
+	if err != nil {
+		t.Fatalf("ApplyTimeoutExpiry: %v", err)
+	}
+	if outcome.Action != TimeoutSkip || outcome.Discarded != nil {
+		t.Fatalf("outcome = %+v, want skip with no discard", outcome)
+	}
+	if len(p.Hand) != 1 || p.Hand[0] != card.ID {
+		t.Fatalf("hand = %v, want unchanged [%s]", p.Hand, card.ID)
+	}
+	if len(g.DiscardPile) != 0 {
+		t.Fatalf("discard pile = %v, want empty", g.DiscardPile)
+	}
+}
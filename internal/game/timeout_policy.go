@@ -0,0 +1,45 @@
+package game
+
+// TimeoutAction decides what happens automatically when a player's turn
+// timer expires without them acting.
+type TimeoutAction string
+
+const (
+	// TimeoutDrawStockpile auto-draws from the stockpile on timeout and
+	// immediately discards the drawn card, leaving the player's hand as it
+	// was (see ApplyTimeoutExpiry).
+	TimeoutDrawStockpile TimeoutAction = "draw_stockpile"
+	// TimeoutDiscardRandom auto-discards a random card from the player's
+	// hand on timeout, a harsher default for competitive/ranked play.
+	TimeoutDiscardRandom TimeoutAction = "discard_random"
+	// TimeoutSkip does nothing but advance the turn, leaving the player's
+	// hand untouched.
+	TimeoutSkip TimeoutAction = "skip"
+)
+
+// TimeoutPolicy configures what happens when a turn timer expires. It's
+// per-game so casual and ranked modes can default differently.
+type TimeoutPolicy struct {
+	Action TimeoutAction
+	// GrantFreshTurnOnResume, if true, resets the turn timer to a full
+	// Duration when a game is restored after a crash rather than
+	// preserving elapsed time, so players aren't penalized for server
+	// downtime they had no part in.
+	GrantFreshTurnOnResume bool
+}
+
+// DefaultTimeoutPolicy returns the policy used when a game doesn't specify
+// its own.
+func DefaultTimeoutPolicy() TimeoutPolicy {
+	return TimeoutPolicy{Action: TimeoutDrawStockpile}
+}
+
+// ResumeTimer reconciles g.Timer after restoring from a snapshot: if
+// TimeoutPolicy.GrantFreshTurnOnResume is set, the current player gets a
+// full fresh turn; otherwise the timer's StartedAt is left as restored, so
+// time elapsed before the crash still counts.
+func (g *Game) ResumeTimer() {
+	if g.Timer != nil && g.TimeoutPolicy.GrantFreshTurnOnResume {
+		g.Timer.Reset()
+	}
+}
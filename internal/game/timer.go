@@ -0,0 +1,38 @@
+package game
+
+import "time"
+
+// TurnTimer tracks how long the current player has had the floor. It is
+// reset by Game.Advance and read by the server's scheduler to decide when
+// to nudge or auto-skip a slow player.
+type TurnTimer struct {
+	Duration  time.Duration
+	StartedAt time.Time
+}
+
+// NewTurnTimer starts a timer for the given per-turn duration.
+func NewTurnTimer(d time.Duration) *TurnTimer {
+	return &TurnTimer{Duration: d, StartedAt: time.Now()}
+}
+
+// Reset restarts the timer, e.g. when the turn advances.
+func (t *TurnTimer) Reset() {
+	t.StartedAt = time.Now()
+}
+
+// Elapsed returns how long the current turn has been running.
+func (t *TurnTimer) Elapsed() time.Duration {
+	return time.Since(t.StartedAt)
+}
+
+// Remaining returns how much time is left before the turn times out. It can
+// be negative once the timer has expired.
+func (t *TurnTimer) Remaining() time.Duration {
+	return t.Duration - t.Elapsed()
+}
+
+// PastHalf reports whether the turn is more than halfway through its
+// budget, used to decide when to send an inactivity nudge.
+func (t *TurnTimer) PastHalf() bool {
+	return t.Elapsed() >= t.Duration/2
+}
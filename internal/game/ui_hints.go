@@ -0,0 +1,15 @@
+package game
+
+// AvailableActions reports which actions userID may currently take,
+// reusing the same authorization matrix the server enforces server-side so
+// the client can gray out buttons without duplicating the rules and
+// without the two ever disagreeing.
+func (g *Game) AvailableActions(userID string) []ActionType {
+	var out []ActionType
+	for action := range allowedStatuses {
+		if g.Authorize(userID, action) == nil {
+			out = append(out, action)
+		}
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package game
+
+// VoteKick tracks in-progress consensus to remove a disruptive player
+// (AFK or griefing) mid-game. Unlike AbortVote, it doesn't require
+// unanimity: a strict majority of connected players other than the target
+// is enough, since requiring every player (including possibly the
+// target's allies) would make the feature useless against the exact
+// behavior it targets.
+type VoteKick struct {
+	TargetID string
+	Votes    map[string]bool // userID -> voted to kick TargetID
+}
+
+// NewVoteKick opens a vote to kick targetID.
+func NewVoteKick(targetID string) *VoteKick {
+	return &VoteKick{TargetID: targetID, Votes: make(map[string]bool)}
+}
+
+// CastVote records userID's vote to kick the target. A vote from the
+// target themselves is accepted but can never tip a majority since
+// Passed only counts voters other than the target.
+func (v *VoteKick) CastVote(userID string) {
+	v.Votes[userID] = true
+}
+
+// Passed reports whether a strict majority of connected players other
+// than the target have voted to kick.
+func (v *VoteKick) Passed(g *Game) bool {
+	eligible, votes := 0, 0
+	for _, p := range g.Players {
+		if !p.Connected || p.UserID == v.TargetID {
+			continue
+		}
+		eligible++
+		if v.Votes[p.UserID] {
+			votes++
+		}
+	}
+	return eligible > 0 && votes*2 > eligible
+}
+
+// StartVoteKick opens a new vote to kick targetID, replacing any previous
+// vote-kick in progress.
+func (g *Game) StartVoteKick(targetID string) *VoteKick {
+	g.VoteKick = NewVoteKick(targetID)
+	return g.VoteKick
+}
+
+// ResolveVoteKick forfeits the vote's target if it has passed, removing
+// them from turn order and clearing the vote either way. It reports
+// whether the kick took effect.
+func (g *Game) ResolveVoteKick() bool {
+	if g.VoteKick == nil {
+		return false
+	}
+	passed := g.VoteKick.Passed(g)
+	if passed {
+		g.ForfeitPlayer(g.VoteKick.TargetID)
+	}
+	g.VoteKick = nil
+	return passed
+}
+
+// ForfeitPlayer removes userID from turn order and marks them Forfeited.
+// Per HouseRules.FreezeForfeitedHand, their hand either stays frozen out
+// of play or is folded back into the discard pile for the rest of the
+// round to draw from.
+func (g *Game) ForfeitPlayer(userID string) {
+	if g.Forfeited == nil {
+		g.Forfeited = make(map[string]bool)
+	}
+	g.Forfeited[userID] = true
+
+	kickedIdx := -1
+	for i, p := range g.Players {
+		if p.UserID == userID {
+			p.Connected = false
+			kickedIdx = i
+			if !g.HouseRules.FreezeForfeitedHand {
+				for _, cardID := range p.Hand {
+					if c, ok := g.Cards[cardID]; ok {
+						g.DiscardPile = append(g.DiscardPile, c)
+					}
+				}
+				p.Hand = nil
+				p.Slots = nil
+			}
+			break
+		}
+	}
+	if kickedIdx == -1 || len(g.Players) == 0 {
+		return
+	}
+	// If it was the forfeited player's turn, advance past them immediately
+	// rather than leaving the game stuck waiting on a player who can no
+	// longer act.
+	if g.TurnIdx == kickedIdx {
+		g.Advance()
+	}
+}
@@ -0,0 +1,51 @@
+package historian
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+)
+
+// CompressLog serializes and gzip-compresses a game's event log, for
+// archival storage once the ring buffer and hot log no longer need it.
+// Action logs are highly repetitive JSON, so this typically shrinks them
+// substantially.
+func (h *Historian) CompressLog(gameID string) ([]byte, error) {
+	events := h.Log(gameID)
+	raw, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(raw); err != nil {
+		gz.Close()
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecompressLog reverses CompressLog, returning the original GameEvent
+// slice.
+func DecompressLog(compressed []byte) ([]GameEvent, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	raw, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, err
+	}
+	var events []GameEvent
+	if err := json.Unmarshal(raw, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
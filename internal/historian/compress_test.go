@@ -0,0 +1,24 @@
+package historian
+
+import "testing"
+
+func TestCompressLogRoundTrip(t *testing.T) {
+	h := New()
+	h.Record("g1", EventGameStarted, map[string]interface{}{"players": 2.0})
+	h.Record("g1", EventGameEnded, map[string]interface{}{"winner": "u1"})
+
+	compressed, err := h.CompressLog("g1")
+	if err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	events, err := DecompressLog(compressed)
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Type != EventGameStarted || events[1].Type != EventGameEnded {
+		t.Errorf("events out of order or wrong type: %+v", events)
+	}
+}
@@ -0,0 +1,200 @@
+// Package historian records the append-only log of GameEvents for each game,
+// giving us a source of truth for replay, dispute resolution, and analytics
+// independent of whatever the live in-memory game state currently looks
+// like.
+package historian
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType names the kind of GameEvent being recorded.
+type EventType string
+
+const (
+	EventGameStarted  EventType = "game_started"
+	EventActionTaken  EventType = "action_taken"
+	EventTurnAdvanced EventType = "turn_advanced"
+	EventGameEnded    EventType = "game_ended"
+	// EventCardKnowledge records a player's belief about a card's identity
+	// at the moment they acted on it, feeding post-game card-counting stats.
+	EventCardKnowledge EventType = "card_knowledge"
+	// EventVoteKickResolved records the outcome of a vote-kick, whether or
+	// not it passed.
+	EventVoteKickResolved EventType = "vote_kick_resolved"
+	// EventGameChat records an in-game chat message.
+	EventGameChat EventType = "game_chat"
+	// EventSnapAttempt records the outcome of a single snap attempt,
+	// including the snapper's measured reaction time and latency bucket,
+	// feeding post-game snap-race fairness analytics.
+	EventSnapAttempt EventType = "snap_attempt"
+	// EventGameLengthCapReached records that a game was auto-ended (or
+	// voided, for ranked) because it hit its configured HouseRules length
+	// cap rather than ending through ordinary play.
+	EventGameLengthCapReached EventType = "game_length_cap_reached"
+	// EventPlayerResign records a player voluntarily forfeiting via
+	// action_resign, distinct from a disconnect-driven forfeit.
+	EventPlayerResign EventType = "player_resign"
+	// EventPlayerForfeitedDisconnect records a player being forfeited after
+	// HouseRules.DisconnectGraceSec expired without them reconnecting; see
+	// ws.RunDisconnectGraceScheduler.
+	EventPlayerForfeitedDisconnect EventType = "player_forfeited_disconnect"
+	// EventGameForceEnded records an operator ending a stuck game directly,
+	// bypassing ordinary win conditions; see admin.Handlers.ForceEnd.
+	EventGameForceEnded EventType = "game_force_ended"
+	// EventAdminPlayerKicked records an operator removing a player from a
+	// game directly, distinct from EventVoteKickResolved since it bypasses
+	// player consensus entirely; see admin.Handlers.Kick.
+	EventAdminPlayerKicked EventType = "admin_player_kicked"
+	// EventCambiaCalled records a player calling cambia via action_call_cambia,
+	// moving the game into StatusFinalRound; see game.Game.CallCambia.
+	EventCambiaCalled EventType = "cambia_called"
+	// EventCardDrawn records a player drawing from the stockpile or discard
+	// pile via draw_stockpile/draw_discard; see game.Game.DrawStockpile and
+	// game.Game.DrawDiscard. The drawn card itself isn't included for a
+	// stockpile draw, since it's private to the drawer until discarded.
+	EventCardDrawn EventType = "card_drawn"
+	// EventCardDiscarded records a player discarding via the discard
+	// action, ending their turn; see game.Game.Discard.
+	EventCardDiscarded EventType = "card_discarded"
+	// EventTurnTimedOut records a turn timer expiring without the player
+	// acting and TimeoutPolicy.Action being applied on their behalf; see
+	// game.Game.ApplyTimeoutExpiry and ws.RunTurnExpiryScheduler.
+	EventTurnTimedOut EventType = "turn_timed_out"
+)
+
+// GameEvent is a single immutable record of something that happened in a
+// game. Payload is kept as a loosely-typed map rather than a union type so
+// new event kinds don't require touching the historian itself.
+type GameEvent struct {
+	GameID string `json:"game_id"`
+	// Seq is this event's 0-based index within its game's log, i.e. the
+	// same value EventsSince's sinceIndex addresses. It's carried on the
+	// event itself (rather than left implicit in slice position) so a
+	// batched eventBatchEvent (see internal/ws/event_envelope.go) still
+	// lets a client tell which events it's missing after a gap.
+	Seq       int                    `json:"seq"`
+	Type      EventType              `json:"type"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+	// GameTimeMillis is milliseconds elapsed since this game's first
+	// recorded event, independent of Timestamp's absolute wall-clock
+	// value. Replay playback should scale off this, not Timestamp, so a
+	// mid-game server restart (which doesn't reset game time, but does
+	// make Timestamp gaps meaningless) can't distort pacing.
+	GameTimeMillis int64 `json:"game_time_ms"`
+}
+
+// Historian appends GameEvents per game and is safe for concurrent use
+// across the goroutines handling a game's connections. It also mirrors
+// recent events into a RingBuffer for live debugging; see ring.go.
+type Historian struct {
+	mu   sync.Mutex
+	logs map[string][]GameEvent
+	ring *RingBuffer
+
+	// gameStart records the Timestamp of each game's first recorded
+	// event, as the zero point for GameTimeMillis.
+	gameStart map[string]time.Time
+
+	// OnEvent, if set, is called with every recorded event after it's
+	// durably appended, e.g. to fan it out to webhook sinks. It must not
+	// block for long: Record holds no lock while calling it, but a slow
+	// OnEvent still delays the caller that triggered the event.
+	OnEvent func(GameEvent)
+
+	// commits, commitTiming, and lastOnEventLagNanos feed CommitStats; see
+	// metrics.go. They're updated outside h.mu so reading them never
+	// contends with Record's hot path.
+	commits             int64
+	commitTiming        commitNanos
+	lastOnEventLagNanos int64
+}
+
+// New returns an empty Historian.
+func New() *Historian {
+	return &Historian{
+		logs:      make(map[string][]GameEvent),
+		ring:      NewRingBuffer(),
+		gameStart: make(map[string]time.Time),
+	}
+}
+
+// Record appends an event to the given game's log.
+func (h *Historian) Record(gameID string, typ EventType, payload map[string]interface{}) {
+	commitStart := time.Now()
+	now := commitStart
+	h.mu.Lock()
+	start, ok := h.gameStart[gameID]
+	if !ok {
+		start = now
+		h.gameStart[gameID] = start
+	}
+	e := GameEvent{
+		GameID:         gameID,
+		Seq:            len(h.logs[gameID]),
+		Type:           typ,
+		Payload:        payload,
+		Timestamp:      now,
+		GameTimeMillis: now.Sub(start).Milliseconds(),
+	}
+	h.logs[gameID] = append(h.logs[gameID], e)
+	h.mu.Unlock()
+	h.ring.Push(e)
+	atomic.AddInt64(&h.commits, 1)
+	h.commitTiming.observe(time.Since(commitStart))
+	if h.OnEvent != nil {
+		h.OnEvent(e)
+		atomic.StoreInt64(&h.lastOnEventLagNanos, int64(time.Since(e.Timestamp)))
+	}
+}
+
+// Recent returns the last ~500 GameEvents recorded for gameID, for live
+// debugging and bug report attachments. It is cheaper than Log because it
+// never replays the full durable history.
+func (h *Historian) Recent(gameID string) []GameEvent {
+	return h.ring.Recent(gameID)
+}
+
+// Log returns a copy of the recorded events for a game, in order.
+func (h *Historian) Log(gameID string) []GameEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	src := h.logs[gameID]
+	out := make([]GameEvent, len(src))
+	copy(out, src)
+	return out
+}
+
+// GameIDs returns every game ID the Historian currently holds a log for,
+// for callers that need to aggregate across games (e.g. global analytics)
+// rather than replaying one game's history.
+func (h *Historian) GameIDs() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ids := make([]string, 0, len(h.logs))
+	for id := range h.logs {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// EventsSince returns the events recorded after index sinceIndex (0-based,
+// exclusive), for a reconnecting client resuming from the last event ID it
+// saw rather than replaying the full log.
+func (h *Historian) EventsSince(gameID string, sinceIndex int) []GameEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	src := h.logs[gameID]
+	if sinceIndex < 0 || sinceIndex >= len(src) {
+		if sinceIndex >= len(src) {
+			return nil
+		}
+		sinceIndex = -1
+	}
+	out := make([]GameEvent, len(src)-sinceIndex-1)
+	copy(out, src[sinceIndex+1:])
+	return out
+}
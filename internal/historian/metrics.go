@@ -0,0 +1,74 @@
+package historian
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// CommitStats summarizes Record's recent performance. Historian writes
+// synchronously under h.mu rather than through an async queue or a
+// separate database (see Historian's doc comment), so "queue length" and
+// "DB error rate" don't apply to this implementation; what's actually
+// measurable is how long each commit itself takes and, via OnEvent, how
+// far delivery to sinks (e.g. the webhook in cmd/server) lags behind the
+// event's own Timestamp.
+type CommitStats struct {
+	TotalCommits     int64
+	LastCommitMs     float64
+	MaxCommitMs      float64
+	LastOnEventLagMs float64
+}
+
+// commitNanos stores commit durations as int64 nanoseconds, so they can be
+// read/written atomically without a mutex on Historian's hot Record path.
+type commitNanos struct {
+	last int64 // atomic, nanoseconds
+	max  int64 // atomic, nanoseconds
+}
+
+func (c *commitNanos) observe(d time.Duration) {
+	atomic.StoreInt64(&c.last, int64(d))
+	for {
+		cur := atomic.LoadInt64(&c.max)
+		if int64(d) <= cur {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&c.max, cur, int64(d)) {
+			return
+		}
+	}
+}
+
+// CommitStats returns a snapshot of h's recent Record performance.
+func (h *Historian) CommitStats() CommitStats {
+	return CommitStats{
+		TotalCommits:     atomic.LoadInt64(&h.commits),
+		LastCommitMs:     float64(atomic.LoadInt64(&h.commitTiming.last)) / float64(time.Millisecond),
+		MaxCommitMs:      float64(atomic.LoadInt64(&h.commitTiming.max)) / float64(time.Millisecond),
+		LastOnEventLagMs: float64(atomic.LoadInt64(&h.lastOnEventLagNanos)) / float64(time.Millisecond),
+	}
+}
+
+// RunLagAlertJob periodically checks h's commit latency and logs a warning
+// when it exceeds maxCommitLatency, standing in for a real paging
+// integration the same way hub.OnDormantTurnApproaching's log line does
+// for push notifications; see RunPruneJob for the analogous prune loop.
+func (h *Historian) RunLagAlertJob(interval, maxCommitLatency time.Duration) (stop chan struct{}) {
+	stop = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if stats := h.CommitStats(); time.Duration(stats.LastCommitMs*float64(time.Millisecond)) > maxCommitLatency {
+					log.Printf("historian: commit latency %.1fms exceeds alert threshold %s", stats.LastCommitMs, maxCommitLatency)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return stop
+}
@@ -0,0 +1,19 @@
+package historian
+
+import "fmt"
+
+// VerifyReplay re-derives state by folding apply over every recorded event
+// for gameID and compares the result against want, confirming the log is
+// sufficient to deterministically reconstruct the game with no hidden
+// non-determinism (e.g. unseeded randomness) sneaking into state
+// transitions.
+func (h *Historian) VerifyReplay(gameID string, initial interface{}, apply func(state interface{}, e GameEvent) interface{}, want interface{}, equal func(a, b interface{}) bool) error {
+	state := initial
+	for _, e := range h.Log(gameID) {
+		state = apply(state, e)
+	}
+	if !equal(state, want) {
+		return fmt.Errorf("replay of game %s diverged from expected final state", gameID)
+	}
+	return nil
+}
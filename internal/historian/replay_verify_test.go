@@ -0,0 +1,23 @@
+package historian
+
+import "testing"
+
+func TestVerifyReplayDetectsDivergence(t *testing.T) {
+	h := New()
+	h.Record("g1", EventActionTaken, map[string]interface{}{"delta": 1.0})
+	h.Record("g1", EventActionTaken, map[string]interface{}{"delta": 2.0})
+
+	apply := func(state interface{}, e GameEvent) interface{} {
+		sum := state.(float64)
+		delta, _ := e.Payload["delta"].(float64)
+		return sum + delta
+	}
+	equal := func(a, b interface{}) bool { return a.(float64) == b.(float64) }
+
+	if err := h.VerifyReplay("g1", 0.0, apply, 3.0, equal); err != nil {
+		t.Errorf("expected replay to match, got: %v", err)
+	}
+	if err := h.VerifyReplay("g1", 0.0, apply, 99.0, equal); err == nil {
+		t.Error("expected replay divergence to be detected")
+	}
+}
@@ -0,0 +1,48 @@
+package historian
+
+import "time"
+
+// DefaultRetention is how long a finished game's full event log is kept
+// before PruneOlderThan removes it, balancing dispute-resolution windows
+// against unbounded memory growth.
+const DefaultRetention = 7 * 24 * time.Hour
+
+// PruneOlderThan removes the full logs (not the short-lived ring buffer)
+// for any game whose last recorded event is older than maxAge. It's meant
+// to be run periodically by a background job.
+func (h *Historian) PruneOlderThan(maxAge time.Duration) (pruned int) {
+	cutoff := time.Now().Add(-maxAge)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for gameID, log := range h.logs {
+		if len(log) == 0 {
+			continue
+		}
+		last := log[len(log)-1]
+		if last.Timestamp.Before(cutoff) {
+			delete(h.logs, gameID)
+			delete(h.gameStart, gameID)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// RunPruneJob periodically prunes logs older than retention until stopped
+// via the returned channel being closed.
+func (h *Historian) RunPruneJob(interval, retention time.Duration) (stop chan struct{}) {
+	stop = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				h.PruneOlderThan(retention)
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return stop
+}
@@ -0,0 +1,38 @@
+package historian
+
+// RewindResult is what TruncateLast removed from a game's log, so a caller
+// reproducing a bug can see exactly how far back it rewound.
+type RewindResult struct {
+	Removed   []GameEvent `json:"removed"`
+	Remaining int         `json:"remaining"`
+}
+
+// TruncateLast drops the last n recorded events for gameID, for
+// admin.Handlers.Rewind to reproduce a player-reported bug against an
+// earlier point in a game's history. n <= 0 or n beyond the log's length
+// is clamped rather than erroring.
+//
+// This only rewinds the historian's own log, not any live *game.Game:
+// there's no generic event-to-state folding function in this codebase
+// (VerifyReplay's apply is supplied per-caller for tests, not a real
+// reverse-mutation path for the live engine), so the in-memory game an
+// operator is debugging is left exactly as it was. A caller wanting a
+// genuinely rewound, playable game has to restore it from an earlier
+// persist.SnapshotStore snapshot instead, if one happens to exist from
+// before the rewound point — see persist.Recover.
+func (h *Historian) TruncateLast(gameID string, n int) RewindResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	src := h.logs[gameID]
+	if n <= 0 {
+		return RewindResult{Remaining: len(src)}
+	}
+	if n > len(src) {
+		n = len(src)
+	}
+	cut := len(src) - n
+	removed := make([]GameEvent, n)
+	copy(removed, src[cut:])
+	h.logs[gameID] = src[:cut]
+	return RewindResult{Removed: removed, Remaining: cut}
+}
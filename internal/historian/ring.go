@@ -0,0 +1,83 @@
+package historian
+
+import "sync"
+
+// ringSize is how many recent GameEvents we keep per game for live
+// debugging. This is intentionally much shorter than the full historian
+// log: it exists so "the server skipped my turn" reports can be diagnosed
+// by eyeballing recent activity without replaying the whole game.
+const ringSize = 500
+
+// ring is a fixed-capacity circular buffer of GameEvents for one game.
+type ring struct {
+	buf   []GameEvent
+	start int // index of the oldest element
+	count int
+}
+
+func newRing() *ring {
+	return &ring{buf: make([]GameEvent, ringSize)}
+}
+
+func (r *ring) push(e GameEvent) {
+	idx := (r.start + r.count) % ringSize
+	r.buf[idx] = e
+	if r.count < ringSize {
+		r.count++
+	} else {
+		r.start = (r.start + 1) % ringSize
+	}
+}
+
+func (r *ring) snapshot() []GameEvent {
+	out := make([]GameEvent, r.count)
+	for i := 0; i < r.count; i++ {
+		out[i] = r.buf[(r.start+i)%ringSize]
+	}
+	return out
+}
+
+// RingBuffer is a Historian-side facility that mirrors the last ringSize
+// events per game for cheap, always-available live debugging, independent
+// of whatever durable storage the full log goes to.
+type RingBuffer struct {
+	mu    sync.Mutex
+	rings map[string]*ring
+}
+
+// NewRingBuffer returns an empty RingBuffer.
+func NewRingBuffer() *RingBuffer {
+	return &RingBuffer{rings: make(map[string]*ring)}
+}
+
+// Push records an event into the ring for its game.
+func (rb *RingBuffer) Push(e GameEvent) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	r := rb.rings[e.GameID]
+	if r == nil {
+		r = newRing()
+		rb.rings[e.GameID] = r
+	}
+	r.push(e)
+}
+
+// Recent returns up to the last ringSize events recorded for gameID, oldest
+// first.
+func (rb *RingBuffer) Recent(gameID string) []GameEvent {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	r := rb.rings[gameID]
+	if r == nil {
+		return nil
+	}
+	return r.snapshot()
+}
+
+// Drop evicts a game's ring, e.g. once the game has finished and any bug
+// report window has passed.
+func (rb *RingBuffer) Drop(gameID string) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	delete(rb.rings, gameID)
+}
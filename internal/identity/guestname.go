@@ -0,0 +1,79 @@
+// Package identity generates and tracks display names for ephemeral
+// guests, who have no Username on their User record.
+package identity
+
+import (
+	"fmt"
+	"sync"
+)
+
+var adjectives = []string{
+	"Swift", "Quiet", "Lucky", "Clever", "Bold", "Hidden", "Nimble", "Sly",
+	"Brisk", "Wary", "Merry", "Stoic", "Plucky", "Vivid", "Restless",
+}
+
+var nouns = []string{
+	"Fox", "Otter", "Hawk", "Heron", "Lynx", "Raven", "Badger", "Wren",
+	"Falcon", "Marten", "Osprey", "Stoat", "Tern", "Vole", "Kite",
+}
+
+// GenerateName deterministically picks an adjective-noun pair from seed
+// (e.g. a counter or hash of the guest's UserID), so the same seed always
+// produces the same base name before collision avoidance kicks in.
+func GenerateName(seed int) string {
+	a := adjectives[seed%len(adjectives)]
+	n := nouns[(seed/len(adjectives))%len(nouns)]
+	return a + n
+}
+
+// LobbyNames tracks display names already claimed within one lobby, so two
+// guests never show up as the same name at the same table.
+type LobbyNames struct {
+	mu     sync.Mutex
+	taken  map[string]bool
+	nextID int
+}
+
+// NewLobbyNames returns an empty per-lobby name tracker.
+func NewLobbyNames() *LobbyNames {
+	return &LobbyNames{taken: make(map[string]bool)}
+}
+
+// Assign generates a guest name unique within this lobby, appending a
+// numeric suffix ("SwiftFox2") if the base name collides.
+func (l *LobbyNames) Assign() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	base := GenerateName(l.nextID)
+	l.nextID++
+	name := base
+	for suffix := 2; l.taken[name]; suffix++ {
+		name = fmt.Sprintf("%s%d", base, suffix)
+	}
+	l.taken[name] = true
+	return name
+}
+
+// SetCustom claims name for a guest who picked their own, avoiding a
+// collision the same way Assign does by appending a numeric suffix.
+// It returns the name actually claimed, which may differ from the
+// requested one.
+func (l *LobbyNames) SetCustom(requested string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	name := requested
+	for suffix := 2; l.taken[name]; suffix++ {
+		name = fmt.Sprintf("%s%d", requested, suffix)
+	}
+	l.taken[name] = true
+	return name
+}
+
+// Release frees name for reuse, e.g. when its holder leaves the lobby.
+func (l *LobbyNames) Release(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.taken, name)
+}
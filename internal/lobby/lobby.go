@@ -0,0 +1,442 @@
+// Package lobby manages pre-game state: players gathering before a match
+// starts, including the start countdown.
+package lobby
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/identity"
+)
+
+// ErrNotHost and ErrNotAMember are TransferHost's failure modes: the
+// caller isn't the current host, or the proposed new host isn't actually
+// seated in the lobby. Exported so callers (e.g. api.LobbyHandlers) can
+// tell them apart with errors.Is to pick the right structured error code.
+var (
+	ErrNotHost    = errors.New("lobby: caller is not the current host")
+	ErrNotAMember = errors.New("lobby: target is not a member of this lobby")
+)
+
+// defaultCountdown is how long a host-started countdown (see
+// StartCountdown) runs before the game starts, unless the host overrides
+// it via SetCountdownDuration.
+const defaultCountdown = 15 * time.Second
+
+// defaultInviteTTL is how long a generated join code stays valid before a
+// host has to regenerate it, so a leaked or screenshotted code can't be
+// used to join indefinitely.
+const defaultInviteTTL = 24 * time.Hour
+
+// inviteCodeAlphabet excludes visually ambiguous characters (0/O, 1/I) so
+// a code read aloud or handwritten is never misheard or mistyped.
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const inviteCodeLength = 6
+
+// Lobby is a group of players waiting to start a game together.
+type Lobby struct {
+	mu sync.Mutex
+
+	ID         string
+	HostID     string
+	Members    []string
+	Spectators []string
+	// Waitlist holds joiners who arrived after Members filled up to
+	// HouseRules.MaxPlayers, in join order. A waitlisted userID is also
+	// added to Spectators, so they can watch while waiting; RemoveMember
+	// promotes the earliest entry into Members as soon as a seat frees up.
+	Waitlist    []string
+	Countdown   time.Duration
+	startAt     *time.Time
+	names       *identity.LobbyNames
+	DisplayName map[string]string // userID -> display name, guests and bots included
+	HouseRules  game.HouseRules
+
+	InviteCode      string
+	InviteExpiresAt time.Time
+
+	// Invites tracks per-user invite status for userIDs explicitly invited
+	// (e.g. via a bulk friend-group invite), as opposed to InviteCode,
+	// which is a single shared join link with no per-invitee tracking.
+	Invites map[string]InviteStatus
+
+	// practiceGameID is the throwaway solo-practice game a member started
+	// while waiting for the real game to fill, if any; see
+	// SetPracticeGame.
+	practiceGameID string
+}
+
+// Counts reports how many players and spectators are currently connected,
+// for lobby_update broadcasts and the public lobby browser.
+func (l *Lobby) Counts() (players, spectators int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.Members), len(l.Spectators)
+}
+
+// AddSpectator seats userID as a spectator, distinct from Members so
+// spectator counts don't affect player-count-gated actions like starting
+// the countdown.
+func (l *Lobby) AddSpectator(userID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Spectators = append(l.Spectators, userID)
+}
+
+// RemoveSpectator removes userID from the spectator list, e.g. on
+// disconnect.
+func (l *Lobby) RemoveSpectator(userID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, id := range l.Spectators {
+		if id == userID {
+			l.Spectators = append(l.Spectators[:i], l.Spectators[i+1:]...)
+			return
+		}
+	}
+}
+
+// NewLobby creates a lobby with the default countdown duration and a
+// freshly generated invite code.
+func NewLobby(id, hostID string) *Lobby {
+	l := &Lobby{
+		ID:          id,
+		HostID:      hostID,
+		Countdown:   defaultCountdown,
+		names:       identity.NewLobbyNames(),
+		DisplayName: make(map[string]string),
+		HouseRules:  game.DefaultHouseRules(),
+		Invites:     make(map[string]InviteStatus),
+	}
+	l.RegenerateInviteCode()
+	return l
+}
+
+// InviteStatus tracks a single invitee's response to an explicit,
+// per-user lobby invite.
+type InviteStatus string
+
+const (
+	InvitePending  InviteStatus = "pending"
+	InviteAccepted InviteStatus = "accepted"
+)
+
+// InviteMember records userID as pending-invited to the lobby, e.g. as
+// part of a bulk friend-group invite. Re-inviting someone who already
+// accepted leaves their status as accepted rather than reverting it.
+func (l *Lobby) InviteMember(userID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.Invites == nil {
+		l.Invites = make(map[string]InviteStatus)
+	}
+	if l.Invites[userID] == InviteAccepted {
+		return
+	}
+	l.Invites[userID] = InvitePending
+}
+
+// AcceptInvite marks userID's invite as accepted, e.g. once they actually
+// join via their invite. It's a no-op if userID was never invited.
+func (l *Lobby) AcceptInvite(userID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, invited := l.Invites[userID]; invited {
+		l.Invites[userID] = InviteAccepted
+	}
+}
+
+// InviteStatuses returns a copy of the lobby's per-user invite statuses,
+// for serializing into lobby state broadcasts.
+func (l *Lobby) InviteStatuses() map[string]InviteStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make(map[string]InviteStatus, len(l.Invites))
+	for userID, status := range l.Invites {
+		out[userID] = status
+	}
+	return out
+}
+
+// generateInviteCode returns a random inviteCodeLength-character code
+// drawn from inviteCodeAlphabet. It uses crypto/rand rather than
+// math/rand since an invite code is a capability: anyone who guesses one
+// can join the lobby.
+func generateInviteCode() string {
+	buf := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err) // crypto/rand failing means the OS RNG is broken; nothing useful to do but stop.
+	}
+	code := make([]byte, inviteCodeLength)
+	for i, b := range buf {
+		code[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return string(code)
+}
+
+// RegenerateInviteCode replaces the lobby's join code with a new one and
+// resets its expiry, invalidating the old code immediately. It's the same
+// operation whether called at lobby creation or by the host later to
+// revoke a leaked code.
+func (l *Lobby) RegenerateInviteCode() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.InviteCode = generateInviteCode()
+	l.InviteExpiresAt = time.Now().Add(defaultInviteTTL)
+	return l.InviteCode
+}
+
+// InviteValid reports whether code matches this lobby's current invite
+// code and hasn't expired.
+func (l *Lobby) InviteValid(code string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return code != "" && code == l.InviteCode && time.Now().Before(l.InviteExpiresAt)
+}
+
+// SetHouseRules replaces the lobby's configured house rules, validating
+// the deck configuration so an invalid setting can't reach game start.
+func (l *Lobby) SetHouseRules(rules game.HouseRules) error {
+	if err := rules.Deck.Validate(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.HouseRules = rules
+	return nil
+}
+
+// AssignGuestName generates a unique adjective-noun display name for
+// userID within this lobby, recording it in DisplayName.
+func (l *Lobby) AssignGuestName(userID string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	name := l.names.Assign()
+	l.DisplayName[userID] = name
+	return name
+}
+
+// SetDisplayName lets a guest pick their own temporary display name,
+// avoiding a collision with anyone else currently at the table. It
+// returns the name actually claimed, which may differ from requested.
+func (l *Lobby) SetDisplayName(userID, requested string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if old, ok := l.DisplayName[userID]; ok {
+		l.names.Release(old)
+	}
+	name := l.names.SetCustom(requested)
+	l.DisplayName[userID] = name
+	return name
+}
+
+// SetCountdownDuration lets the host customize how long the countdown runs
+// once started. It only takes effect for the next StartCountdown call.
+func (l *Lobby) SetCountdownDuration(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Countdown = d
+}
+
+// StartCountdown begins the countdown to game start, manually triggered by
+// the host rather than auto-starting once the lobby fills. Calling it again
+// before the previous countdown elapses restarts the clock.
+func (l *Lobby) StartCountdown() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	at := time.Now().Add(l.Countdown)
+	l.startAt = &at
+	return at
+}
+
+// CancelCountdown aborts a running countdown, e.g. if a player leaves
+// before the game starts.
+func (l *Lobby) CancelCountdown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.startAt = nil
+}
+
+// Ready reports whether the countdown has elapsed and the game should start.
+func (l *Lobby) Ready() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.startAt != nil && !time.Now().Before(*l.startAt)
+}
+
+// Deadline returns the countdown's target time and true if one is running,
+// false if CancelCountdown was called (or StartCountdown never was). See
+// ws.RunLobbyCountdownScheduler, which schedules against this rather than
+// polling Ready on a fixed interval.
+func (l *Lobby) Deadline() (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.startAt == nil {
+		return time.Time{}, false
+	}
+	return *l.startAt, true
+}
+
+// AddMember seats userID in the lobby and assigns it a generated guest
+// display name, so every member has a name to show before any of them set
+// a username or custom display name. Once Members reaches
+// HouseRules.MaxPlayers, further joiners are waitlisted instead: added to
+// Waitlist and, so they have something to do while waiting, Spectators.
+// waitlisted reports which happened.
+func (l *Lobby) AddMember(userID string) (waitlisted bool) {
+	l.mu.Lock()
+	if l.HouseRules.MaxPlayers > 0 && len(l.Members) >= l.HouseRules.MaxPlayers {
+		l.Waitlist = append(l.Waitlist, userID)
+		l.Spectators = append(l.Spectators, userID)
+		waitlisted = true
+	} else {
+		l.Members = append(l.Members, userID)
+	}
+	l.mu.Unlock()
+	l.AssignGuestName(userID)
+	l.AcceptInvite(userID)
+	return waitlisted
+}
+
+// botIDPrefix marks a Members entry as a bot seat rather than a human
+// player, so downstream code (game setup, the bot runner) can tell them
+// apart without a separate membership list.
+const botIDPrefix = "bot:"
+
+// FillWithBots pads Members up to size with bot seats, so a lobby host
+// doesn't need the full human player count to start. It's a no-op if the
+// lobby already has size or more members. newBotID is called once per seat
+// added, typically uuid.NewString() prefixed by the caller.
+func (l *Lobby) FillWithBots(size int, newBotID func() string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for len(l.Members) < size {
+		l.Members = append(l.Members, botIDPrefix+newBotID())
+	}
+}
+
+// IsBot reports whether userID names a bot seat added by FillWithBots.
+func IsBot(userID string) bool {
+	return strings.HasPrefix(userID, botIDPrefix)
+}
+
+// IsHost reports whether userID is the lobby's current host.
+func (l *Lobby) IsHost(userID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.HostID == userID
+}
+
+// RemoveMember removes userID from Members (and, if present, Spectators or
+// Waitlist). If this frees a seat under HouseRules.MaxPlayers, the
+// earliest-waitlisted joiner (if any) is promoted into Members and out of
+// spectate-only Spectators; promotedUserID is "" if nobody was waiting. If
+// userID was the host, host duties automatically migrate to the
+// longest-connected remaining human member — since Members is appended to
+// in join order and never reordered, that's simply the first non-bot
+// entry left. newHostID is "" if no eligible member remains (e.g. the
+// lobby is now empty or only bots remain), in which case migrated is still
+// true but callers should treat the lobby as hostless rather than retry.
+func (l *Lobby) RemoveMember(userID string) (newHostID string, migrated bool, promotedUserID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, id := range l.Members {
+		if id == userID {
+			l.Members = append(l.Members[:i], l.Members[i+1:]...)
+			break
+		}
+	}
+	for i, id := range l.Spectators {
+		if id == userID {
+			l.Spectators = append(l.Spectators[:i], l.Spectators[i+1:]...)
+			break
+		}
+	}
+	for i, id := range l.Waitlist {
+		if id == userID {
+			l.Waitlist = append(l.Waitlist[:i], l.Waitlist[i+1:]...)
+			break
+		}
+	}
+
+	if len(l.Waitlist) > 0 && (l.HouseRules.MaxPlayers == 0 || len(l.Members) < l.HouseRules.MaxPlayers) {
+		promotedUserID = l.Waitlist[0]
+		l.Waitlist = l.Waitlist[1:]
+		l.Members = append(l.Members, promotedUserID)
+		for i, id := range l.Spectators {
+			if id == promotedUserID {
+				l.Spectators = append(l.Spectators[:i], l.Spectators[i+1:]...)
+				break
+			}
+		}
+	}
+
+	if l.HostID != userID {
+		return "", false, promotedUserID
+	}
+	for _, id := range l.Members {
+		if !IsBot(id) {
+			l.HostID = id
+			return id, true, promotedUserID
+		}
+	}
+	l.HostID = ""
+	return "", true, promotedUserID
+}
+
+// TransferHost hands off host duties from currentHostID to targetUserID,
+// an explicit "transfer_host" action only the current host can perform
+// (as opposed to RemoveMember's automatic migration on disconnect).
+func (l *Lobby) TransferHost(currentHostID, targetUserID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.HostID != currentHostID {
+		return ErrNotHost
+	}
+	for _, id := range l.Members {
+		if id == targetUserID {
+			l.HostID = targetUserID
+			return nil
+		}
+	}
+	return ErrNotAMember
+}
+
+// BotUserID tags id as a bot seat, the same way FillWithBots does, for
+// callers that need to add a single bot seat outside a lobby's Members
+// list (e.g. a solo practice game).
+func BotUserID(id string) string {
+	return botIDPrefix + id
+}
+
+// SetPracticeGame records the ID of the solo-practice game a member
+// started while waiting, so it can be torn down once it's no longer
+// needed.
+func (l *Lobby) SetPracticeGame(gameID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.practiceGameID = gameID
+}
+
+// PracticeGame returns the currently tracked practice game ID, or "" if
+// none is running.
+func (l *Lobby) PracticeGame() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.practiceGameID
+}
+
+// ClearPracticeGame forgets the tracked practice game, e.g. once it's been
+// torn down.
+func (l *Lobby) ClearPracticeGame() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.practiceGameID = ""
+}
@@ -0,0 +1,43 @@
+package lobby
+
+import "time"
+
+// Template is a saved set of lobby settings (house rules, countdown
+// duration, max players) that a host can reuse instead of reconfiguring a
+// new lobby from scratch every time.
+type Template struct {
+	ID        string            `json:"id"`
+	OwnerID   string            `json:"owner_id"`
+	Name      string            `json:"name"`
+	Settings  map[string]string `json:"settings"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// TemplateStore persists lobby templates per owner. It's intentionally
+// in-memory for now, matching the rest of the server's stores; swapping in
+// durable storage later only touches this file.
+type TemplateStore struct {
+	byOwner map[string][]*Template
+}
+
+// NewTemplateStore returns an empty TemplateStore.
+func NewTemplateStore() *TemplateStore {
+	return &TemplateStore{byOwner: make(map[string][]*Template)}
+}
+
+// Save adds or replaces a template for its owner.
+func (s *TemplateStore) Save(t *Template) {
+	list := s.byOwner[t.OwnerID]
+	for i, existing := range list {
+		if existing.ID == t.ID {
+			list[i] = t
+			return
+		}
+	}
+	s.byOwner[t.OwnerID] = append(list, t)
+}
+
+// List returns all templates saved by ownerID.
+func (s *TemplateStore) List(ownerID string) []*Template {
+	return s.byOwner[ownerID]
+}
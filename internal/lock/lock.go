@@ -0,0 +1,74 @@
+// Package lock provides single-writer mutual exclusion for mutations that
+// must not race across server instances, e.g. two lobby hosts on different
+// nodes starting the same game at once. Callers depend only on Locker; a
+// real multi-instance deployment backs it with Redis (SET NX plus a TTL),
+// the same way internal/broadcast decouples cross-instance fan-out from its
+// in-memory default.
+package lock
+
+import (
+	"sync"
+	"time"
+)
+
+// Locker acquires named, time-limited, mutually exclusive locks with
+// fencing tokens: a caller that held a lock past its TTL (e.g. after a long
+// GC pause) can compare the token it was issued against the current holder
+// before committing a write, instead of trusting that it's still the only
+// writer just because Acquire once said so.
+type Locker interface {
+	// Acquire attempts to take the lock named key for ttl. ok is false if
+	// someone else currently holds it; token is only meaningful when
+	// ok is true.
+	Acquire(key string, ttl time.Duration) (token int64, ok bool)
+	// Release gives up key if it's still held with token. It's a no-op,
+	// not an error, if token is stale (the lock expired and was
+	// reacquired by someone else) or key isn't held at all.
+	Release(key string, token int64)
+}
+
+// held is one lock's current state.
+type held struct {
+	token     int64
+	expiresAt time.Time
+}
+
+// MemoryLocker is the single-instance default Locker: it serializes
+// mutations within this process only, with no cross-instance visibility.
+// Used in development and tests, and as the fallback when no distributed
+// backend is configured.
+type MemoryLocker struct {
+	mu        sync.Mutex
+	locks     map[string]held
+	nextToken int64
+}
+
+// NewMemoryLocker returns an empty MemoryLocker.
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{locks: make(map[string]held)}
+}
+
+// Acquire implements Locker.
+func (m *MemoryLocker) Acquire(key string, ttl time.Duration) (token int64, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if h, exists := m.locks[key]; exists && now.Before(h.expiresAt) {
+		return 0, false
+	}
+
+	m.nextToken++
+	token = m.nextToken
+	m.locks[key] = held{token: token, expiresAt: now.Add(ttl)}
+	return token, true
+}
+
+// Release implements Locker.
+func (m *MemoryLocker) Release(key string, token int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h, exists := m.locks[key]; exists && h.token == token {
+		delete(m.locks, key)
+	}
+}
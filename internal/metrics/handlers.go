@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Handlers bundles the live-state callbacks and running counters that
+// feed /metrics. The gauges are DI funcs rather than values this package
+// maintains itself, so ActiveGames/ActiveLobbies/ConnectedWebsockets are
+// always read live off the actual stores (store.GameStore, the lobby map,
+// ws.Hub) instead of a separately-tracked count that could drift from
+// reality.
+type Handlers struct {
+	ActiveGames         func() int
+	ActiveLobbies       func() int
+	ConnectedWebsockets func() int
+
+	// ActionsProcessed counts every inbound game-websocket action handled
+	// by handleGameMessage, incremented there. Prometheus convention
+	// exposes this as a counter and leaves rate-per-second to the scraper
+	// (e.g. PromQL's rate()) rather than computing it server-side.
+	ActionsProcessed *Counter
+	SnapSuccess      *Counter
+	SnapFail         *Counter
+
+	// HistorianQueueDepth, if set, reports the historian's pending-write
+	// backlog. historian.Historian writes synchronously under its own
+	// mutex in this codebase (see internal/historian) rather than through
+	// an async queue, so there's nothing to actually measure yet; this
+	// field exists so a future async historian can wire in without
+	// changing the metrics contract. Nil reports 0.
+	HistorianQueueDepth func() int
+
+	// HistorianCommitMsLast/Max and HistorianOnEventLagMs, if set, report
+	// historian.Historian.CommitStats(); see that type's doc comment for
+	// why there's no queue-length or DB-error-rate gauge to go with them.
+	HistorianCommitMsLast func() float64
+	HistorianCommitMsMax  func() float64
+	HistorianOnEventLagMs func() float64
+
+	// TimerWheelScheduled/FiredTotal/CanceledTotal/LastLagMs/MaxLagMs, if
+	// set, report ws.TimerWheel.Stats() for the wheel driving turn/
+	// countdown expirations; see ws.RunTurnExpiryScheduler and
+	// ws.RunLobbyCountdownScheduler.
+	TimerWheelScheduled     func() int
+	TimerWheelFiredTotal    func() int64
+	TimerWheelCanceledTotal func() int64
+	TimerWheelLastLagMs     func() float64
+	TimerWheelMaxLagMs      func() float64
+
+	HTTPLatency *LatencyRegistry
+}
+
+// New returns a Handlers with its counters and latency registry
+// initialized; the gauge callbacks are left nil until the caller sets
+// them (they need references to stores that don't exist yet at this
+// point in main.go's setup).
+func New() *Handlers {
+	return &Handlers{
+		ActionsProcessed: &Counter{},
+		SnapSuccess:      &Counter{},
+		SnapFail:         &Counter{},
+		HTTPLatency:      NewLatencyRegistry(),
+	}
+}
+
+func (h *Handlers) gaugeOrZero(fn func() int) int64 {
+	if fn == nil {
+		return 0
+	}
+	return int64(fn())
+}
+
+func (h *Handlers) gaugeOrZeroFloat(fn func() float64) float64 {
+	if fn == nil {
+		return 0
+	}
+	return fn()
+}
+
+func (h *Handlers) counterOrZero(fn func() int64) int64 {
+	if fn == nil {
+		return 0
+	}
+	return fn()
+}
+
+// ServeHTTP writes every tracked metric in Prometheus text exposition
+// format. It's registered directly as the /metrics handler rather than
+// wrapped by Instrument, so scraping itself doesn't inflate its own
+// latency numbers.
+func (h *Handlers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writeGauge(w, "cambia_active_games", h.gaugeOrZero(h.ActiveGames))
+	writeGauge(w, "cambia_active_lobbies", h.gaugeOrZero(h.ActiveLobbies))
+	writeGauge(w, "cambia_connected_websockets", h.gaugeOrZero(h.ConnectedWebsockets))
+	writeGauge(w, "cambia_historian_queue_depth", h.gaugeOrZero(h.HistorianQueueDepth))
+	writeGaugeFloat(w, "cambia_historian_commit_latency_ms", h.gaugeOrZeroFloat(h.HistorianCommitMsLast))
+	writeGaugeFloat(w, "cambia_historian_commit_latency_max_ms", h.gaugeOrZeroFloat(h.HistorianCommitMsMax))
+	writeGaugeFloat(w, "cambia_historian_on_event_lag_ms", h.gaugeOrZeroFloat(h.HistorianOnEventLagMs))
+	writeCounter(w, "cambia_actions_processed_total", h.ActionsProcessed.Value())
+	writeCounter(w, "cambia_snap_success_total", h.SnapSuccess.Value())
+	writeCounter(w, "cambia_snap_fail_total", h.SnapFail.Value())
+	writeGauge(w, "cambia_timer_wheel_scheduled", h.gaugeOrZero(h.TimerWheelScheduled))
+	writeCounter(w, "cambia_timer_wheel_fired_total", h.counterOrZero(h.TimerWheelFiredTotal))
+	writeCounter(w, "cambia_timer_wheel_canceled_total", h.counterOrZero(h.TimerWheelCanceledTotal))
+	writeGaugeFloat(w, "cambia_timer_wheel_last_lag_ms", h.gaugeOrZeroFloat(h.TimerWheelLastLagMs))
+	writeGaugeFloat(w, "cambia_timer_wheel_max_lag_ms", h.gaugeOrZeroFloat(h.TimerWheelMaxLagMs))
+
+	for label, s := range h.HTTPLatency.snapshot() {
+		fmt.Fprintf(w, "cambia_http_request_duration_seconds_sum{handler=%q} %g\n", label, s.sumSeconds)
+		fmt.Fprintf(w, "cambia_http_request_duration_seconds_count{handler=%q} %d\n", label, s.count)
+	}
+}
+
+func writeGauge(w io.Writer, name string, v int64) {
+	fmt.Fprintf(w, "# TYPE %s gauge\n%s %d\n", name, name, v)
+}
+
+func writeGaugeFloat(w io.Writer, name string, v float64) {
+	fmt.Fprintf(w, "# TYPE %s gauge\n%s %g\n", name, name, v)
+}
+
+func writeCounter(w io.Writer, name string, v int64) {
+	fmt.Fprintf(w, "# TYPE %s counter\n%s %d\n", name, name, v)
+}
+
+// Instrument wraps next so every request's duration is recorded against
+// label in HTTPLatency before the response is written back to the
+// client.
+func (h *Handlers) Instrument(label string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next(w, r)
+		h.HTTPLatency.Observe(label, time.Since(start))
+	}
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// latencySample accumulates a handler's request count and total duration,
+// the minimum needed to derive an average in a dashboard. This is
+// deliberately not a full histogram with buckets (no client library here
+// to define/merge them), so p50/p99-style queries aren't available from
+// this endpoint the way they would be with the real prometheus client.
+type latencySample struct {
+	count      int64
+	sumSeconds float64
+}
+
+// LatencyRegistry tracks per-handler HTTP latency, keyed by a caller-
+// supplied label (typically the route pattern).
+type LatencyRegistry struct {
+	mu      sync.Mutex
+	samples map[string]*latencySample
+}
+
+// NewLatencyRegistry returns an empty LatencyRegistry.
+func NewLatencyRegistry() *LatencyRegistry {
+	return &LatencyRegistry{samples: make(map[string]*latencySample)}
+}
+
+// Observe records a single completed request's duration against label.
+func (r *LatencyRegistry) Observe(label string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.samples[label]
+	if s == nil {
+		s = &latencySample{}
+		r.samples[label] = s
+	}
+	s.count++
+	s.sumSeconds += d.Seconds()
+}
+
+// snapshot returns a copy of every label's accumulated sample, safe to
+// read without holding r.mu.
+func (r *LatencyRegistry) snapshot() map[string]latencySample {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make(map[string]latencySample, len(r.samples))
+	for label, s := range r.samples {
+		out[label] = *s
+	}
+	return out
+}
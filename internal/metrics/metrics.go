@@ -0,0 +1,32 @@
+// Package metrics exposes operational counters and gauges in the
+// Prometheus text exposition format, hand-rolled against the stdlib
+// rather than vendoring the prometheus client library, which this
+// module's go.mod doesn't carry and can't be fetched in every environment
+// this repo builds in.
+package metrics
+
+import "sync/atomic"
+
+// Counter is a monotonically increasing value, e.g. total actions
+// processed. Safe for concurrent use.
+type Counter struct {
+	v int64
+}
+
+// Inc increments the counter by 1.
+func (c *Counter) Inc() { atomic.AddInt64(&c.v, 1) }
+
+// Value returns the counter's current total.
+func (c *Counter) Value() int64 { return atomic.LoadInt64(&c.v) }
+
+// Gauge is a value that can move up or down, e.g. active connections.
+// Safe for concurrent use.
+type Gauge struct {
+	v int64
+}
+
+// Set replaces the gauge's value.
+func (g *Gauge) Set(v int64) { atomic.StoreInt64(&g.v, v) }
+
+// Value returns the gauge's current value.
+func (g *Gauge) Value() int64 { return atomic.LoadInt64(&g.v) }
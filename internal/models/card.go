@@ -0,0 +1,90 @@
+package models
+
+// Suit identifies a playing card's suit. Cambia is played with a standard
+// 52-card deck plus jokers, so Suit has no "none" zero value beyond jokers.
+type Suit string
+
+const (
+	SuitSpades   Suit = "spades"
+	SuitHearts   Suit = "hearts"
+	SuitDiamonds Suit = "diamonds"
+	SuitClubs    Suit = "clubs"
+	SuitJoker    Suit = "joker"
+)
+
+// Rank identifies a card's face value independent of its point value, since
+// several ranks (e.g. jokers, red kings) score differently than they rank.
+type Rank string
+
+const (
+	RankAce   Rank = "A"
+	RankTwo   Rank = "2"
+	RankThree Rank = "3"
+	RankFour  Rank = "4"
+	RankFive  Rank = "5"
+	RankSix   Rank = "6"
+	RankSeven Rank = "7"
+	RankEight Rank = "8"
+	RankNine  Rank = "9"
+	RankTen   Rank = "10"
+	RankJack  Rank = "J"
+	RankQueen Rank = "Q"
+	RankKing  Rank = "K"
+	RankJoker Rank = "joker"
+)
+
+// Card is a single physical card tracked by the server. ID is a
+// server-generated identifier distinct from Rank/Suit so that duplicate
+// cards (e.g. two queens) remain individually addressable across the
+// stockpile, discard pile, and player hands.
+type Card struct {
+	ID   string `json:"id"`
+	Rank Rank   `json:"rank"`
+	Suit Suit   `json:"suit"`
+}
+
+// Value returns the card's point value under standard Cambia scoring.
+func (c Card) Value() int {
+	switch c.Rank {
+	case RankJoker:
+		return -1
+	case RankKing:
+		if c.Suit == SuitHearts || c.Suit == SuitDiamonds {
+			return -1
+		}
+		return 13
+	case RankQueen:
+		return 12
+	case RankJack:
+		return 11
+	case RankAce:
+		return 1
+	default:
+		return rankNumericValue(c.Rank)
+	}
+}
+
+func rankNumericValue(r Rank) int {
+	switch r {
+	case RankTwo:
+		return 2
+	case RankThree:
+		return 3
+	case RankFour:
+		return 4
+	case RankFive:
+		return 5
+	case RankSix:
+		return 6
+	case RankSeven:
+		return 7
+	case RankEight:
+		return 8
+	case RankNine:
+		return 9
+	case RankTen:
+		return 10
+	default:
+		return 0
+	}
+}
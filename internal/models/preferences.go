@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// HandSortMode controls how a player's hand is ordered when the server
+// serializes it for sync. Index positions are part of the protocol (special
+// actions reference slot numbers), so changing a player's mode mid-game is
+// intentionally not supported.
+type HandSortMode string
+
+const (
+	// HandSortAcquisition keeps cards in the order they were drawn/swapped
+	// into the hand, which is the server's natural internal order.
+	HandSortAcquisition HandSortMode = "acquisition"
+	// HandSortSlot orders by fixed slot number only, ignoring acquisition
+	// order, so a player's layout never visually reshuffles after a snap or
+	// penalty card is inserted.
+	HandSortSlot HandSortMode = "slot"
+)
+
+// NotificationPreferences controls which events a user wants pushed to
+// them outside of an active game session (e.g. via the push notification
+// channel, not the in-game WS feed).
+type NotificationPreferences struct {
+	TurnReminders  bool `json:"turn_reminders"`
+	FriendRequests bool `json:"friend_requests"`
+	LobbyInvites   bool `json:"lobby_invites"`
+	// WeeklyDigest opts a user into the weekly personal-stats digest; see
+	// internal/digest. Off by default, since unlike the other preferences
+	// above it's a new, unsolicited-by-default channel rather than an
+	// in-the-moment alert.
+	WeeklyDigest bool `json:"weekly_digest"`
+	// DigestDay is which day of the week WeeklyDigest is sent on, only
+	// meaningful when WeeklyDigest is true.
+	DigestDay time.Weekday `json:"digest_day"`
+}
+
+// DefaultNotificationPreferences returns the notification defaults applied
+// to new users: on for everything except turn reminders, which many
+// players find noisy once they know the game well.
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		TurnReminders:  false,
+		FriendRequests: true,
+		LobbyInvites:   true,
+		WeeklyDigest:   false,
+		DigestDay:      time.Sunday,
+	}
+}
+
+// Preferences are per-player settings that affect server-side behavior
+// rather than just client rendering.
+type Preferences struct {
+	HandSort      HandSortMode            `json:"hand_sort"`
+	Notifications NotificationPreferences `json:"notifications"`
+}
+
+// DefaultPreferences returns the preferences applied to new users.
+func DefaultPreferences() Preferences {
+	return Preferences{
+		HandSort:      HandSortAcquisition,
+		Notifications: DefaultNotificationPreferences(),
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// User is a registered or ephemeral-guest account. Guests have an empty
+// Username and are identified only by ID for the lifetime of their session.
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username,omitempty"`
+	IsGuest  bool   `json:"is_guest"`
+	// IsAdmin grants access to internal/admin's HTTP endpoints; see
+	// admin.RequireAdmin. Never set by any public-facing handler.
+	IsAdmin     bool        `json:"is_admin,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Preferences Preferences `json:"preferences"`
+}
+
+// Player is a User's participation in a single Game, tracking per-game
+// state that doesn't belong on the account itself.
+//
+// Hand and Slots both describe a player's cards but serve different index
+// semantics: Hand is acquisition order (append on draw/swap-in, remove on
+// play-out), while Slots is a fixed-size layout where a card's position
+// never moves once assigned, and a vacated slot stays empty until refilled.
+// Which one is serialized to the client is decided by the player's
+// HandSortMode preference so slot indices stay predictable for snaps and
+// penalties regardless of draw order.
+type Player struct {
+	UserID      string   `json:"user_id"`
+	DisplayName string   `json:"display_name,omitempty"` // guest-generated or self-chosen; carried over from the lobby
+	Hand        []string `json:"hand"`                   // card IDs, acquisition order
+	Slots       []string `json:"slots"`                  // card IDs by fixed slot, "" if empty
+	Connected   bool     `json:"connected"`
+}
+
+// OrderedHand returns the player's cards (card IDs, "" for empty slots)
+// arranged per mode. Index semantics for HandSortSlot are stable across
+// snaps and penalty insertions because Slots positions don't shift; for
+// HandSortAcquisition, indices shift as cards are added/removed.
+func (p *Player) OrderedHand(mode HandSortMode) []string {
+	if mode == HandSortSlot && p.Slots != nil {
+		return p.Slots
+	}
+	return p.Hand
+}
+
+// AssignSlot places cardID into the first empty slot, growing Slots if
+// every existing slot is occupied. It returns the slot index used.
+func (p *Player) AssignSlot(cardID string) int {
+	for i, c := range p.Slots {
+		if c == "" {
+			p.Slots[i] = cardID
+			return i
+		}
+	}
+	p.Slots = append(p.Slots, cardID)
+	return len(p.Slots) - 1
+}
+
+// VacateSlot clears the slot holding cardID, if any, leaving a stable empty
+// slot behind rather than shifting later cards down.
+func (p *Player) VacateSlot(cardID string) {
+	for i, c := range p.Slots {
+		if c == cardID {
+			p.Slots[i] = ""
+			return
+		}
+	}
+}
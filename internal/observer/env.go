@@ -0,0 +1,172 @@
+// Package observer exposes the game engine directly to programmatic
+// agents, bypassing websockets and the lobby/historian machinery, for
+// self-play training loops that need to step a game synchronously and
+// read back observations and rewards in the same goroutine.
+package observer
+
+import (
+	"fmt"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// Agent decides what action a seat takes given the current Observation.
+// It mirrors bot.Policy's shape rather than reusing it directly, since a
+// training agent needs the full Observation (including its own private
+// knowledge) rather than just a *game.Game and a userID.
+type Agent interface {
+	Decide(obs Observation) (game.ActionType, bool)
+}
+
+// Observation is everything one seat is allowed to see about the current
+// game state: public state plus that seat's own private card knowledge,
+// never another seat's.
+type Observation struct {
+	GameID           string
+	Status           game.Status
+	TurnIdx          int
+	CurrentPlayer    string
+	UserID           string
+	AvailableActions []game.ActionType
+	Knowledge        map[string]models.Card
+}
+
+// StepResult is returned from Env.Step: the resulting Observation for the
+// acting seat, a shaped reward, and whether the episode has ended.
+type StepResult struct {
+	Observation Observation
+	Reward      float64
+	Done        bool
+}
+
+// Env wraps a *game.Game for single-process, synchronous stepping. It is
+// not safe for concurrent use; callers training multiple episodes in
+// parallel should construct one Env per goroutine.
+type Env struct {
+	Game *game.Game
+}
+
+// New constructs an Env with one seat per playerID, ready to step once
+// Status is advanced out of StatusWaiting by the caller (mirroring the
+// same pregame-to-active transition a real lobby would drive).
+func New(gameID string, mode game.Mode, playerIDs []string) *Env {
+	g := game.NewGameWithMode(gameID, mode)
+	for _, id := range playerIDs {
+		g.AddPlayer(id)
+	}
+	return &Env{Game: g}
+}
+
+// Reset re-creates the underlying game with the same players and mode,
+// discarding all prior state, so a training loop can start a fresh
+// episode without re-allocating an Env.
+func (e *Env) Reset() {
+	ids := make([]string, len(e.Game.Players))
+	for i, p := range e.Game.Players {
+		ids[i] = p.UserID
+	}
+	e.Game = game.NewGameWithMode(e.Game.ID, e.Game.Mode)
+	for _, id := range ids {
+		e.Game.AddPlayer(id)
+	}
+}
+
+// Observe builds the Observation for userID from the current game state.
+func (e *Env) Observe(userID string) Observation {
+	g := e.Game
+	current := ""
+	if p := g.CurrentPlayer(); p != nil {
+		current = p.UserID
+	}
+	return Observation{
+		GameID:           g.ID,
+		Status:           g.Status,
+		TurnIdx:          g.TurnIdx,
+		CurrentPlayer:    current,
+		UserID:           userID,
+		AvailableActions: g.AvailableActions(userID),
+		Knowledge:        g.PrivateKnowledge(userID),
+	}
+}
+
+// Step authorizes and applies a single agent-chosen action for userID. A
+// draw leaves the turn open (the agent still needs to discard); a discard
+// applies and then advances the turn, the same two-step shape a real
+// client drives via draw_stockpile/draw_discard followed by discard.
+func (e *Env) Step(userID string, action game.ActionType) (StepResult, error) {
+	g := e.Game
+	g.Mu.Lock()
+	if err := g.Authorize(userID, action); err != nil {
+		g.Mu.Unlock()
+		return StepResult{}, fmt.Errorf("observer: %w", err)
+	}
+
+	switch action {
+	case game.ActionCallCambia:
+		g.CallCambia(userID)
+	case game.ActionDrawStockpile:
+		if _, err := g.DrawStockpile(userID); err != nil {
+			g.Mu.Unlock()
+			return StepResult{}, fmt.Errorf("observer: %w", err)
+		}
+	case game.ActionDrawDiscard:
+		if _, err := g.DrawDiscard(userID); err != nil {
+			g.Mu.Unlock()
+			return StepResult{}, fmt.Errorf("observer: %w", err)
+		}
+	case game.ActionDiscard:
+		// Decide only names an ActionType, not a specific card, so this
+		// discards the most recently acquired card (Hand is acquisition
+		// order; see models.Player) rather than requiring Agent to also
+		// choose among hand indices.
+		for _, p := range g.Players {
+			if p.UserID == userID && len(p.Hand) > 0 {
+				if _, err := g.Discard(userID, p.Hand[len(p.Hand)-1]); err != nil {
+					g.Mu.Unlock()
+					return StepResult{}, fmt.Errorf("observer: %w", err)
+				}
+				break
+			}
+		}
+		g.Advance()
+	case game.ActionSnap:
+		// Scoring/removal for a successful snap isn't implemented yet;
+		// treat it as a pass-through so episodes don't stall on it.
+	default:
+		g.Advance()
+	}
+	g.Mu.Unlock()
+
+	reward := e.reward(userID)
+	done := g.Status.Terminal()
+	return StepResult{Observation: e.Observe(userID), Reward: reward, Done: done}, nil
+}
+
+// reward shapes a per-step signal: 0 while the episode is ongoing, and
+// +1/-1/0 for a win/loss/draw once the game has reached a terminal
+// status, so a training loop doesn't need its own scoring logic.
+func (e *Env) reward(userID string) float64 {
+	g := e.Game
+	if !g.Status.Terminal() {
+		return 0
+	}
+	scores := make(map[string]int, len(g.Players))
+	for _, p := range g.Players {
+		hand := make([]models.Card, 0, len(p.Hand))
+		for _, cardID := range p.Hand {
+			if c, ok := g.Cards[cardID]; ok {
+				hand = append(hand, c)
+			}
+		}
+		scores[p.UserID] = game.HandScoreWithConfig(hand, g.HouseRules.Deck)
+	}
+	winner, ok := game.Winner(scores)
+	if !ok {
+		return 0
+	}
+	if winner == userID {
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,80 @@
+package persist
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileSnapshotStore persists one JSON file per game under Dir. It's the
+// default SnapshotStore: no external dependency to run, at the cost of not
+// working across multiple server instances sharing no filesystem.
+type FileSnapshotStore struct {
+	mu  sync.Mutex
+	Dir string
+}
+
+// NewFileSnapshotStore returns a store rooted at dir, creating it if
+// necessary.
+func NewFileSnapshotStore(dir string) (*FileSnapshotStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileSnapshotStore{Dir: dir}, nil
+}
+
+func (s *FileSnapshotStore) path(gameID string) string {
+	return filepath.Join(s.Dir, gameID+".json")
+}
+
+// Save writes data for gameID, overwriting any previous snapshot.
+func (s *FileSnapshotStore) Save(gameID string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return os.WriteFile(s.path(gameID), data, 0o644)
+}
+
+// Load reads the stored snapshot for gameID, if any.
+func (s *FileSnapshotStore) Load(gameID string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.path(gameID))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// Delete removes a game's snapshot, e.g. once it's finished and no longer
+// needs to survive a restart.
+func (s *FileSnapshotStore) Delete(gameID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := os.Remove(s.path(gameID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// List returns the game IDs with a stored snapshot.
+func (s *FileSnapshotStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	return ids, nil
+}
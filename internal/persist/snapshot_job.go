@@ -0,0 +1,76 @@
+package persist
+
+import (
+	"log"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// DefaultSnapshotInterval is how often in-flight games are snapshotted.
+// Shorter intervals bound how much a crash can lose; longer ones reduce
+// write load.
+const DefaultSnapshotInterval = 10 * time.Second
+
+// RunSnapshotJob periodically snapshots every non-terminal game in games
+// into dst. It blocks until the process exits and is meant to be started
+// once in a background goroutine.
+func RunSnapshotJob(games *store.GameStore, dst SnapshotStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		games.ForEach(func(g *game.Game) {
+			g.Mu.Lock()
+			terminal := g.Status.Terminal()
+			data, err := g.MarshalSnapshot()
+			gameID := g.ID
+			g.Mu.Unlock()
+
+			if terminal {
+				if err := dst.Delete(gameID); err != nil {
+					log.Printf("persist: failed to drop snapshot for finished game %s: %v", gameID, err)
+				}
+				return
+			}
+			if err != nil {
+				log.Printf("persist: failed to marshal snapshot for game %s: %v", gameID, err)
+				return
+			}
+			if err := dst.Save(gameID, data); err != nil {
+				log.Printf("persist: failed to save snapshot for game %s: %v", gameID, err)
+			}
+		})
+	}
+}
+
+// Recover rehydrates every stored snapshot into games, for use on server
+// startup before accepting connections. It returns the number of games
+// restored.
+func Recover(src SnapshotStore, games *store.GameStore) (int, error) {
+	ids, err := src.List()
+	if err != nil {
+		return 0, err
+	}
+	restored := 0
+	for _, id := range ids {
+		data, ok, err := src.Load(id)
+		if err != nil {
+			log.Printf("persist: failed to load snapshot for game %s: %v", id, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		g, err := game.UnmarshalSnapshot(data)
+		if err != nil {
+			log.Printf("persist: failed to restore game %s: %v", id, err)
+			continue
+		}
+		g.ResumeTimer()
+		games.Put(g)
+		restored++
+	}
+	return restored, nil
+}
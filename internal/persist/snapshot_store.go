@@ -0,0 +1,18 @@
+// Package persist durably stores game snapshots so in-flight games survive
+// a server restart. The SnapshotStore interface is backend-agnostic;
+// FileSnapshotStore is the default implementation used when no external
+// store (Postgres, Redis) is configured.
+package persist
+
+// SnapshotStore durably stores the latest snapshot bytes for a game. A
+// production deployment with multiple server instances would back this
+// with Postgres or Redis instead of FileSnapshotStore, but the interface
+// stays the same either way.
+type SnapshotStore interface {
+	Save(gameID string, data []byte) error
+	Load(gameID string) ([]byte, bool, error)
+	Delete(gameID string) error
+	// List returns the IDs of every game with a stored snapshot, for
+	// recovery on startup.
+	List() ([]string, error)
+}
@@ -0,0 +1,37 @@
+// Package preflight runs startup checks and warmup work before the server
+// accepts traffic, so a cold start fails fast on misconfiguration instead
+// of accepting connections it can't actually serve.
+package preflight
+
+import "fmt"
+
+// Check is a single named startup verification. Run stops at the first
+// failing check.
+type Check struct {
+	Name string
+	Run  func() error
+}
+
+// RunAll executes every check in order, returning an error identifying the
+// first one that failed.
+func RunAll(checks []Check) error {
+	for _, c := range checks {
+		if err := c.Run(); err != nil {
+			return fmt.Errorf("preflight check %q failed: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// Warmup does best-effort work that makes the first real requests faster
+// (e.g. building a standard deck once to warm allocator paths) but isn't
+// required for correctness, so its errors are logged rather than fatal.
+func Warmup(fns ...func() error) []error {
+	var errs []error
+	for _, fn := range fns {
+		if err := fn(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
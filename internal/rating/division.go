@@ -0,0 +1,57 @@
+// Package rating tracks competitive standing: seasonal placement,
+// divisions, and the underlying Glicko-2 rating math (see glicko.go).
+package rating
+
+// Division is a coarse seasonal skill tier shown to players, derived from
+// their rating once placement is complete.
+type Division string
+
+const (
+	DivisionBronze   Division = "bronze"
+	DivisionSilver   Division = "silver"
+	DivisionGold     Division = "gold"
+	DivisionPlatinum Division = "platinum"
+	DivisionDiamond  Division = "diamond"
+)
+
+// divisionThresholds maps the minimum rating for each division, checked
+// highest-first.
+var divisionThresholds = []struct {
+	min      float64
+	division Division
+}{
+	{2200, DivisionDiamond},
+	{1900, DivisionPlatinum},
+	{1600, DivisionGold},
+	{1300, DivisionSilver},
+	{0, DivisionBronze},
+}
+
+// DivisionForRating maps a rating to its division.
+func DivisionForRating(rating float64) Division {
+	for _, t := range divisionThresholds {
+		if rating >= t.min {
+			return t.division
+		}
+	}
+	return DivisionBronze
+}
+
+// placementGamesRequired is how many ranked games a player must complete
+// each season before their rating is considered placed and a division is
+// shown publicly.
+const placementGamesRequired = 5
+
+// PlacementStatus describes a player's progress toward seasonal placement.
+type PlacementStatus struct {
+	GamesPlayed int
+	Placed      bool
+}
+
+// RecordPlacementGame advances placement progress by one game.
+func (p *PlacementStatus) RecordPlacementGame() {
+	p.GamesPlayed++
+	if p.GamesPlayed >= placementGamesRequired {
+		p.Placed = true
+	}
+}
@@ -0,0 +1,64 @@
+package rating
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileRatingStore persists one JSON file per (mode, user) under Dir. It's
+// the default PersistentStore: no external dependency to run, at the
+// cost of not working across multiple server instances sharing no
+// filesystem — the same tradeoff persist.FileSnapshotStore makes for
+// game snapshots.
+type FileRatingStore struct {
+	mu  sync.Mutex
+	Dir string
+}
+
+// NewFileRatingStore returns a store rooted at dir, creating it if
+// necessary.
+func NewFileRatingStore(dir string) (*FileRatingStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileRatingStore{Dir: dir}, nil
+}
+
+func (s *FileRatingStore) path(mode Mode, userID string) string {
+	return filepath.Join(s.Dir, string(mode), userID+".json")
+}
+
+// Save writes r for (mode, userID), overwriting any previous value.
+func (s *FileRatingStore) Save(mode Mode, userID string, r Rating) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.path(mode, userID)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0o644)
+}
+
+// Load reads the stored rating for (mode, userID), if any.
+func (s *FileRatingStore) Load(mode Mode, userID string) (Rating, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.path(mode, userID))
+	if os.IsNotExist(err) {
+		return Rating{}, false, nil
+	}
+	if err != nil {
+		return Rating{}, false, err
+	}
+	var r Rating
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Rating{}, false, err
+	}
+	return r, true, nil
+}
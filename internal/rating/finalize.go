@@ -0,0 +1,39 @@
+package rating
+
+// FinalizeRatings folds a finished round's final scores (userID -> Cambia
+// score, lower is better) into every player's Mode rating: each player is
+// scored pairwise against every other player in scores, exactly as
+// ApplyResult does for a single opponent, then updated via one Glicko-2
+// Update call against all of them at once. It returns each player's
+// rating delta, for broadcasting alongside the round result. A player
+// with no opponents in scores is left unrated, matching Update's
+// no-op-on-empty-results behavior.
+func FinalizeRatings(s *Store, mode Mode, scores map[string]int) map[string]float64 {
+	deltas := make(map[string]float64, len(scores))
+	for subjectID, subjectScore := range scores {
+		var results []Result
+		for opponentID, opponentScore := range scores {
+			if opponentID == subjectID {
+				continue
+			}
+			var score float64
+			switch {
+			case subjectScore < opponentScore:
+				score = 1
+			case subjectScore > opponentScore:
+				score = 0
+			default:
+				score = 0.5
+			}
+			results = append(results, Result{Opponent: s.Get(mode, opponentID), Score: score})
+		}
+		if len(results) == 0 {
+			continue
+		}
+		subject := s.Get(mode, subjectID)
+		updated := Update(subject, results)
+		deltas[subjectID] = updated.Value - subject.Value
+		s.Set(mode, subjectID, updated)
+	}
+	return deltas
+}
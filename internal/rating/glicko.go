@@ -0,0 +1,138 @@
+package rating
+
+import "math"
+
+// Glicko-2 constants per Mark Glickman's paper. tau bounds how much
+// volatility can change per rating period; smaller values trust the prior
+// volatility estimate more.
+const (
+	glicko2Scale      = 173.7178
+	defaultVolatility = 0.06
+	tau               = 0.5
+	convergence       = 0.000001
+)
+
+// Rating is a player's Glicko-2 rating state. Value and Deviation are kept
+// in the familiar Glicko (not Glicko-2 internal) scale so they're directly
+// displayable; Volatility has no such public-facing equivalent.
+type Rating struct {
+	Value      float64
+	Deviation  float64
+	Volatility float64
+}
+
+// NewRating returns the standard unrated-player starting point.
+func NewRating() Rating {
+	return Rating{Value: 1500, Deviation: 350, Volatility: defaultVolatility}
+}
+
+// Result is the outcome of a single game against an opponent, from the
+// subject player's point of view: 1 for a win, 0.5 for a draw, 0 for a
+// loss.
+type Result struct {
+	Opponent Rating
+	Score    float64
+}
+
+func toGlicko2Scale(r Rating) (mu, phi float64) {
+	return (r.Value - 1500) / glicko2Scale, r.Deviation / glicko2Scale
+}
+
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func e(mu, muJ, phiJ float64) float64 {
+	return 1 / (1 + math.Exp(-g(phiJ)*(mu-muJ)))
+}
+
+// Update computes a player's new Rating after a rating period's worth of
+// Results, per Glickman's Glicko-2 algorithm. A player with no results
+// this period should instead use DecayDeviation, not Update.
+func Update(subject Rating, results []Result) Rating {
+	if len(results) == 0 {
+		return subject
+	}
+
+	mu, phi := toGlicko2Scale(subject)
+	sigma := subject.Volatility
+
+	var v, deltaSum float64
+	for _, res := range results {
+		muJ, phiJ := toGlicko2Scale(res.Opponent)
+		gPhiJ := g(phiJ)
+		eVal := e(mu, muJ, phiJ)
+		v += gPhiJ * gPhiJ * eVal * (1 - eVal)
+		deltaSum += gPhiJ * (res.Score - eVal)
+	}
+	v = 1 / v
+	delta := v * deltaSum
+
+	newSigma := newVolatility(sigma, phi, v, delta)
+
+	phiStar := math.Sqrt(phi*phi + newSigma*newSigma)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*deltaSum
+
+	return Rating{
+		Value:      newMu*glicko2Scale + 1500,
+		Deviation:  newPhi * glicko2Scale,
+		Volatility: newSigma,
+	}
+}
+
+// newVolatility solves for the new volatility via the Illinois algorithm
+// (a bracketed secant method), as specified by Glicko-2.
+func newVolatility(sigma, phi, v, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		num := ex * (delta*delta - phi*phi - v - ex)
+		den := 2 * (phi*phi + v + ex) * (phi*phi + v + ex)
+		return num/den - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > convergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB < 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
+
+// DecayDeviation widens a player's rating deviation after a rating period
+// with no games, reflecting growing uncertainty about their true skill.
+func DecayDeviation(subject Rating) Rating {
+	_, phi := toGlicko2Scale(subject)
+	phiStar := math.Sqrt(phi*phi + subject.Volatility*subject.Volatility)
+	subject.Deviation = phiStar * glicko2Scale
+	return subject
+}
+
+// PreviewDelta reports the rating change a player would see from a single
+// game against opponent with the given score (1 win / 0.5 draw / 0 loss),
+// without mutating either player's stored rating. Matchmaking and the
+// pre-match UI use this to show the gain/loss range before the game
+// starts; game-end reporting calls Update directly on the true result.
+func PreviewDelta(subject, opponent Rating, score float64) float64 {
+	updated := Update(subject, []Result{{Opponent: opponent, Score: score}})
+	return updated.Value - subject.Value
+}
@@ -0,0 +1,46 @@
+package rating
+
+import "testing"
+
+func TestUpdateRatingIncreasesOnWin(t *testing.T) {
+	subject := NewRating()
+	opponent := NewRating()
+
+	updated := Update(subject, []Result{{Opponent: opponent, Score: 1}})
+	if updated.Value <= subject.Value {
+		t.Fatalf("expected rating to increase after a win, got %v -> %v", subject.Value, updated.Value)
+	}
+}
+
+func TestUpdateRatingDecreasesOnLoss(t *testing.T) {
+	subject := NewRating()
+	opponent := NewRating()
+
+	updated := Update(subject, []Result{{Opponent: opponent, Score: 0}})
+	if updated.Value >= subject.Value {
+		t.Fatalf("expected rating to decrease after a loss, got %v -> %v", subject.Value, updated.Value)
+	}
+}
+
+func TestPreviewDeltaDoesNotMutateInputs(t *testing.T) {
+	subject := NewRating()
+	opponent := NewRating()
+
+	_ = PreviewDelta(subject, opponent, 1)
+	if subject != NewRating() {
+		t.Fatalf("PreviewDelta must not mutate its subject argument")
+	}
+}
+
+func TestBeatingHigherRatedOpponentGainsMoreThanBeatingLowerRated(t *testing.T) {
+	subject := NewRating()
+	strongOpponent := Rating{Value: 1800, Deviation: 50, Volatility: defaultVolatility}
+	weakOpponent := Rating{Value: 1200, Deviation: 50, Volatility: defaultVolatility}
+
+	gainVsStrong := PreviewDelta(subject, strongOpponent, 1)
+	gainVsWeak := PreviewDelta(subject, weakOpponent, 1)
+
+	if gainVsStrong <= gainVsWeak {
+		t.Fatalf("expected beating a stronger opponent to gain more: vs strong=%v, vs weak=%v", gainVsStrong, gainVsWeak)
+	}
+}
@@ -0,0 +1,22 @@
+package rating
+
+// Mode distinguishes which format a rating applies to: a player's skill
+// in 1v1 play says little about their 4-player standing or their circuit
+// standing, so each is tracked as a fully independent Rating.
+type Mode string
+
+const (
+	Mode1v1     Mode = "1v1"
+	Mode4p      Mode = "4p"
+	ModeCircuit Mode = "circuit"
+)
+
+// ModeForPlayerCount returns the rating Mode for a non-circuit ranked
+// game with the given player count. Circuit games use ModeCircuit
+// directly rather than this.
+func ModeForPlayerCount(players int) Mode {
+	if players <= 2 {
+		return Mode1v1
+	}
+	return Mode4p
+}
@@ -0,0 +1,13 @@
+package rating
+
+// PersistentStore durably stores one user's full Glicko-2 state
+// (Value/Deviation/Volatility) per Mode, so ratings survive a server
+// restart instead of resetting to NewRating. FileRatingStore is the
+// default implementation, used when no external store is configured; a
+// production deployment with multiple server instances would back this
+// with a real database instead, but Store only depends on this
+// interface.
+type PersistentStore interface {
+	Save(mode Mode, userID string, r Rating) error
+	Load(mode Mode, userID string) (r Rating, ok bool, err error)
+}
@@ -0,0 +1,86 @@
+package rating
+
+import (
+	"log"
+	"sync"
+)
+
+// ratingKey identifies one user's rating within a single Mode.
+type ratingKey struct {
+	mode   Mode
+	userID string
+}
+
+// Store is a concurrent-safe registry of per-user, per-mode ratings.
+// Players not yet present in a given mode default to NewRating on first
+// Get. If Persist is set, ratings are loaded from and written through to
+// it so they survive a restart; nil (the NewStore default) keeps
+// everything in memory only.
+type Store struct {
+	mu      sync.Mutex
+	ratings map[ratingKey]Rating
+	streaks map[string]Streak
+
+	// Persist, if non-nil, durably stores each (mode, user)'s full
+	// Glicko-2 state; see persist.go.
+	Persist PersistentStore
+}
+
+// NewStore returns an empty, in-memory-only Store.
+func NewStore() *Store {
+	return &Store{ratings: make(map[ratingKey]Rating)}
+}
+
+// Get returns userID's current rating for mode, initializing it (after
+// checking Persist, if set) to NewRating on first appearance.
+func (s *Store) Get(mode Mode, userID string) Rating {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.getLocked(mode, userID)
+}
+
+func (s *Store) getLocked(mode Mode, userID string) Rating {
+	key := ratingKey{mode, userID}
+	if r, ok := s.ratings[key]; ok {
+		return r
+	}
+	if s.Persist != nil {
+		if r, ok, err := s.Persist.Load(mode, userID); err != nil {
+			log.Printf("rating: failed to load %s/%s: %v", mode, userID, err)
+		} else if ok {
+			s.ratings[key] = r
+			return r
+		}
+	}
+	r := NewRating()
+	s.ratings[key] = r
+	return r
+}
+
+// Set overwrites userID's stored rating for mode, e.g. after applying a
+// game result, writing through to Persist if set.
+func (s *Store) Set(mode Mode, userID string, r Rating) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ratings[ratingKey{mode, userID}] = r
+	if s.Persist != nil {
+		if err := s.Persist.Save(mode, userID, r); err != nil {
+			log.Printf("rating: failed to persist %s/%s: %v", mode, userID, err)
+		}
+	}
+}
+
+// ApplyResult updates subject's mode rating against opponent's current
+// rating and score, storing and returning the new rating plus the delta
+// applied.
+func (s *Store) ApplyResult(mode Mode, subjectID, opponentID string, score float64) (updated Rating, delta float64) {
+	s.mu.Lock()
+	subject := s.getLocked(mode, subjectID)
+	opponent := s.getLocked(mode, opponentID)
+	s.mu.Unlock()
+
+	updated = Update(subject, []Result{{Opponent: opponent, Score: score}})
+	delta = updated.Value - subject.Value
+	s.Set(mode, subjectID, updated)
+	return updated, delta
+}
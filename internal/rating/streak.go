@@ -0,0 +1,57 @@
+package rating
+
+// Streak tracks a player's consecutive ranked wins, for profile display
+// and milestone broadcasts. A loss resets Current to 0; a draw leaves
+// Current unchanged, since a draw is neither a win nor the kind of result
+// that should end a streak.
+type Streak struct {
+	Current int `json:"current"`
+	Best    int `json:"best"`
+}
+
+// streakMilestones are the win counts that trigger a streak_milestone
+// event, feeding the (not-yet-built) achievements system.
+var streakMilestones = []int{3, 5, 10}
+
+// RecordStreakResult folds a resolved ranked result (same score convention
+// as ApplyResult: 1 win, 0.5 draw, 0 loss) into subjectID's win streak,
+// returning the updated Streak and, if this result's new Current exactly
+// matches one of streakMilestones, that milestone with hit=true. This is a
+// separate method from ApplyResult rather than folded into it, so callers
+// that don't care about streaks aren't forced to handle a wider return.
+func (s *Store) RecordStreakResult(subjectID string, score float64) (streak Streak, milestone int, hit bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.streaks == nil {
+		s.streaks = make(map[string]Streak)
+	}
+	st := s.streaks[subjectID]
+	switch {
+	case score > 0.5:
+		st.Current++
+		if st.Current > st.Best {
+			st.Best = st.Current
+		}
+	case score < 0.5:
+		st.Current = 0
+	}
+	s.streaks[subjectID] = st
+
+	if score > 0.5 {
+		for _, m := range streakMilestones {
+			if st.Current == m {
+				return st, m, true
+			}
+		}
+	}
+	return st, 0, false
+}
+
+// GetStreak returns subjectID's current win-streak state, zero-valued if
+// they have no ranked results recorded yet.
+func (s *Store) GetStreak(subjectID string) Streak {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.streaks[subjectID]
+}
@@ -0,0 +1,58 @@
+// Package session tracks where each player's live websocket connection is
+// currently owned, so a reconnect after a node failure can be routed to
+// whichever instance now owns the game instead of bouncing with "game not
+// found".
+package session
+
+import "sync"
+
+// Session is one player's connection metadata, kept fresh on every
+// message so a reconnect can resume from LastEventID instead of replaying
+// the whole game.
+type Session struct {
+	UserID      string
+	GameID      string
+	InstanceID  string
+	LastEventID int
+}
+
+// Store is a cross-instance registry of Sessions. The in-memory
+// implementation below only works for a single instance; a real
+// multi-instance deployment backs Store with Redis so every instance sees
+// the same session table, but callers only depend on this interface.
+type Store interface {
+	Put(s Session) error
+	Get(userID, gameID string) (Session, bool, error)
+}
+
+// MemoryStore is the single-instance default Store, used in development
+// and tests. It satisfies Store but provides no cross-instance visibility.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	sessions map[string]Session // key: userID + "|" + gameID
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: make(map[string]Session)}
+}
+
+func key(userID, gameID string) string {
+	return userID + "|" + gameID
+}
+
+// Put records or updates a session.
+func (m *MemoryStore) Put(s Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[key(s.UserID, s.GameID)] = s
+	return nil
+}
+
+// Get looks up a session by user and game.
+func (m *MemoryStore) Get(userID, gameID string) (Session, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.sessions[key(userID, gameID)]
+	return s, ok, nil
+}
@@ -0,0 +1,76 @@
+// Package social tracks relationships between users: friends lists and
+// (eventually) blocks.
+package social
+
+import "sync"
+
+// FriendStore is a concurrent-safe adjacency list of friendships. Add
+// records both directions at once; there is no pending or accept step.
+type FriendStore struct {
+	mu      sync.Mutex
+	friends map[string]map[string]bool // userID -> set of friend userIDs
+}
+
+// NewFriendStore returns an empty FriendStore.
+func NewFriendStore() *FriendStore {
+	return &FriendStore{friends: make(map[string]map[string]bool)}
+}
+
+// Add records a mutual friendship between a and b.
+func (s *FriendStore) Add(a, b string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addOneDirectional(a, b)
+	s.addOneDirectional(b, a)
+}
+
+func (s *FriendStore) addOneDirectional(from, to string) {
+	if s.friends[from] == nil {
+		s.friends[from] = make(map[string]bool)
+	}
+	s.friends[from][to] = true
+}
+
+// List returns userID's friends.
+func (s *FriendStore) List(userID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]string, 0, len(s.friends[userID]))
+	for id := range s.friends[userID] {
+		out = append(out, id)
+	}
+	return out
+}
+
+// BulkImportResult reports the outcome of resolving a list of usernames to
+// friend additions, since some entries may not exist or may already be
+// friends.
+type BulkImportResult struct {
+	Added        []string `json:"added"`
+	NotFound     []string `json:"not_found"`
+	AlreadyAdded []string `json:"already_added"`
+}
+
+// BulkImport resolves a list of usernames via resolve and adds each found
+// user as a friend of userID, reporting which entries succeeded, were
+// already friends, or couldn't be resolved.
+func (s *FriendStore) BulkImport(userID string, usernames []string, resolve func(username string) (userID string, ok bool)) BulkImportResult {
+	var res BulkImportResult
+	for _, name := range usernames {
+		friendID, ok := resolve(name)
+		if !ok {
+			res.NotFound = append(res.NotFound, name)
+			continue
+		}
+		s.mu.Lock()
+		already := s.friends[userID][friendID]
+		s.mu.Unlock()
+		if already {
+			res.AlreadyAdded = append(res.AlreadyAdded, name)
+			continue
+		}
+		s.Add(userID, friendID)
+		res.Added = append(res.Added, name)
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+package social
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrGroupNotFound is returned by GroupStore methods operating on a group
+// ID that doesn't exist.
+var ErrGroupNotFound = errors.New("social: friend group not found")
+
+// FriendGroup is a named, owner-curated subset of a user's friends, saved
+// so they don't have to re-select the same people every time they want to
+// invite them together (e.g. a regular game night group).
+type FriendGroup struct {
+	ID      string
+	OwnerID string
+	Name    string
+	Members []string // userIDs; not required to currently be friends of OwnerID
+}
+
+// GroupStore is a concurrent-safe collection of FriendGroups.
+type GroupStore struct {
+	mu     sync.Mutex
+	groups map[string]*FriendGroup
+}
+
+// NewGroupStore returns an empty GroupStore.
+func NewGroupStore() *GroupStore {
+	return &GroupStore{groups: make(map[string]*FriendGroup)}
+}
+
+// Create saves a new group owned by ownerID and returns it.
+func (s *GroupStore) Create(id, ownerID, name string, members []string) *FriendGroup {
+	g := &FriendGroup{ID: id, OwnerID: ownerID, Name: name, Members: members}
+	s.mu.Lock()
+	s.groups[id] = g
+	s.mu.Unlock()
+	return g
+}
+
+// Get returns the group with the given ID.
+func (s *GroupStore) Get(id string) (*FriendGroup, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[id]
+	return g, ok
+}
+
+// List returns every group owned by ownerID.
+func (s *GroupStore) List(ownerID string) []*FriendGroup {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*FriendGroup, 0)
+	for _, g := range s.groups {
+		if g.OwnerID == ownerID {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// Update replaces id's name and members, so long as ownerID actually owns
+// it.
+func (s *GroupStore) Update(id, ownerID, name string, members []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[id]
+	if !ok {
+		return ErrGroupNotFound
+	}
+	if g.OwnerID != ownerID {
+		return ErrGroupNotFound
+	}
+	g.Name = name
+	g.Members = members
+	return nil
+}
+
+// Delete removes id, so long as ownerID actually owns it.
+func (s *GroupStore) Delete(id, ownerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[id]
+	if !ok {
+		return ErrGroupNotFound
+	}
+	if g.OwnerID != ownerID {
+		return ErrGroupNotFound
+	}
+	delete(s.groups, id)
+	return nil
+}
@@ -0,0 +1,80 @@
+package stats
+
+const (
+	// MinPlausibleReactionMs is the fastest reaction time a human snap
+	// attempt could plausibly clock, below which it's more likely scripted
+	// than fast fingers. It's intentionally generous (real elite reaction
+	// times cluster around 150-200ms) so this flags outliers, not just the
+	// merely skilled.
+	MinPlausibleReactionMs = 100
+
+	// minSnapSampleSize is the fewest attempts a player needs before their
+	// win rate is judged at all; a couple of lucky snaps in a short game
+	// isn't evidence of anything.
+	minSnapSampleSize = 8
+
+	// suspiciousSnapWinRate is the win rate, above minSnapSampleSize
+	// attempts, that's implausible for a human player across a whole game
+	// of real reaction-time variance.
+	suspiciousSnapWinRate = 0.95
+)
+
+// PlayerAnticheatFlag summarizes one player's snap-attempt history for a
+// single game and why, if at all, it looks suspicious. Reasons is empty for
+// a player whose play looked ordinary.
+type PlayerAnticheatFlag struct {
+	UserID            string   `json:"user_id"`
+	Attempts          int      `json:"attempts"`
+	Wins              int      `json:"wins"`
+	WinRate           float64  `json:"win_rate"`
+	SubHumanReactions int      `json:"sub_human_reactions"` // attempts faster than MinPlausibleReactionMs
+	FastestReactionMs int      `json:"fastest_reaction_ms"`
+	Reasons           []string `json:"reasons,omitempty"`
+}
+
+// SummarizeAnticheat groups a game's SnapEvents by player and flags anyone
+// whose snap history looks more consistent with scripted/assisted play than
+// human reflexes. This is a heuristic over what's actually recorded today
+// (reaction time and outcome, not which card was snapped), so it catches
+// implausible speed and implausible consistency, not e.g. a player snapping
+// a card they never legitimately saw: cmd/server's snap_attempt handler
+// records no card ID, so there is nothing to cross-reference against
+// game.Knowledge.
+func SummarizeAnticheat(events []SnapEvent) []PlayerAnticheatFlag {
+	byUser := make(map[string]*PlayerAnticheatFlag)
+	var order []string
+	for _, e := range events {
+		f, ok := byUser[e.UserID]
+		if !ok {
+			f = &PlayerAnticheatFlag{UserID: e.UserID, FastestReactionMs: e.ReactionTimeMs}
+			byUser[e.UserID] = f
+			order = append(order, e.UserID)
+		}
+		f.Attempts++
+		if e.Won {
+			f.Wins++
+		}
+		if e.ReactionTimeMs < f.FastestReactionMs {
+			f.FastestReactionMs = e.ReactionTimeMs
+		}
+		if e.ReactionTimeMs < MinPlausibleReactionMs {
+			f.SubHumanReactions++
+		}
+	}
+
+	out := make([]PlayerAnticheatFlag, 0, len(order))
+	for _, userID := range order {
+		f := byUser[userID]
+		if f.Attempts > 0 {
+			f.WinRate = float64(f.Wins) / float64(f.Attempts)
+		}
+		if f.SubHumanReactions > 0 {
+			f.Reasons = append(f.Reasons, "reaction time below plausible human limits")
+		}
+		if f.Attempts >= minSnapSampleSize && f.WinRate >= suspiciousSnapWinRate {
+			f.Reasons = append(f.Reasons, "snap win rate implausibly high for sample size")
+		}
+		out = append(out, *f)
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+// Package stats computes and records post-game statistics derived from the
+// historian's event log, for players' personal history and review.
+package stats
+
+// CardKnowledgeEvent captures, per card, how much a player actually knew
+// about it before acting, so post-game review can show "you played blind"
+// vs "you had it memorized" without needing the full replay.
+type CardKnowledgeEvent struct {
+	UserID      string `json:"user_id"`
+	CardID      string `json:"card_id"`
+	KnownBefore bool   `json:"known_before"` // player had prior knowledge of this card's identity
+	Correct     bool   `json:"correct"`      // their belief about the card matched reality
+}
+
+// CardCountingSummary aggregates CardKnowledgeEvents for one player across
+// a finished game, for the post-game review screen.
+type CardCountingSummary struct {
+	UserID         string  `json:"user_id"`
+	TotalTracked   int     `json:"total_tracked"`
+	CorrectGuesses int     `json:"correct_guesses"`
+	Accuracy       float64 `json:"accuracy"`
+}
+
+// Summarize reduces a player's CardKnowledgeEvents into a CardCountingSummary.
+func Summarize(userID string, events []CardKnowledgeEvent) CardCountingSummary {
+	s := CardCountingSummary{UserID: userID}
+	for _, e := range events {
+		if e.UserID != userID || !e.KnownBefore {
+			continue
+		}
+		s.TotalTracked++
+		if e.Correct {
+			s.CorrectGuesses++
+		}
+	}
+	if s.TotalTracked > 0 {
+		s.Accuracy = float64(s.CorrectGuesses) / float64(s.TotalTracked)
+	}
+	return s
+}
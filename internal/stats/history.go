@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/rating"
+)
+
+// GameHistoryEntry is one finished game from a player's perspective, for
+// the personal game-history list.
+type GameHistoryEntry struct {
+	GameID       string    `json:"game_id"`
+	TournamentID string    `json:"tournament_id,omitempty"`
+	Score        int       `json:"score"`
+	Won          bool      `json:"won"`
+	EndedAt      time.Time `json:"ended_at"`
+}
+
+// PersonalStats aggregates a player's finished games into the summary
+// numbers their profile and stats page show. It's built from
+// game_ended/snap_attempt historian events, not a dedicated SQL store, so
+// it only reflects games whose end was actually recorded as an event; see
+// api.StatsHandlers.PersonalStats.
+type PersonalStats struct {
+	UserID            string        `json:"user_id"`
+	GamesPlayed       int           `json:"games_played"`
+	Wins              int           `json:"wins"`
+	Losses            int           `json:"losses"`
+	WinRate           float64       `json:"win_rate"`
+	AverageScore      float64       `json:"average_score"`
+	SnapAttempts      int           `json:"snap_attempts"`
+	SnapSuccessRate   float64       `json:"snap_success_rate"`
+	CambiaCalls       int           `json:"cambia_calls"`
+	CambiaSuccessRate float64       `json:"cambia_success_rate"`
+	Streak            rating.Streak `json:"streak"`
+}
+
+// SummarizeHistory reduces userID's GameHistoryEntries into PersonalStats.
+// cambiaCalls/cambiaWins and snapAttempts/snapWins are folded in
+// separately, since they come from different event types than the
+// game-ended entries do; streak comes from the caller's rating.Store
+// directly, since it's not derivable from historian events alone (it
+// depends on ranked-match ordering the historian doesn't track).
+func SummarizeHistory(userID string, games []GameHistoryEntry, snapAttempts, snapWins, cambiaCalls, cambiaWins int, streak rating.Streak) PersonalStats {
+	s := PersonalStats{UserID: userID, SnapAttempts: snapAttempts, CambiaCalls: cambiaCalls, Streak: streak}
+	var totalScore int
+	for _, g := range games {
+		s.GamesPlayed++
+		totalScore += g.Score
+		if g.Won {
+			s.Wins++
+		} else {
+			s.Losses++
+		}
+	}
+	if s.GamesPlayed > 0 {
+		s.WinRate = float64(s.Wins) / float64(s.GamesPlayed)
+		s.AverageScore = float64(totalScore) / float64(s.GamesPlayed)
+	}
+	if snapAttempts > 0 {
+		s.SnapSuccessRate = float64(snapWins) / float64(snapAttempts)
+	}
+	if cambiaCalls > 0 {
+		s.CambiaSuccessRate = float64(cambiaWins) / float64(cambiaCalls)
+	}
+	return s
+}
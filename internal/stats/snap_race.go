@@ -0,0 +1,90 @@
+package stats
+
+import "sort"
+
+// SnapEvent records the outcome of a single snap attempt, timestamped
+// relative to when the snappable card became available so reaction time
+// is comparable across attempts regardless of network latency.
+type SnapEvent struct {
+	UserID         string
+	ReactionTimeMs int
+	LatencyBucket  string // e.g. "0-50", "50-100", computed by the caller from measured RTT
+	Won            bool
+}
+
+// LatencyBucketStats summarizes outcomes for one latency bucket, so
+// players and operators can see whether SnapRace is fair for
+// high-latency connections.
+type LatencyBucketStats struct {
+	Bucket   string  `json:"bucket"`
+	Attempts int     `json:"attempts"`
+	Wins     int     `json:"wins"`
+	WinRate  float64 `json:"win_rate"`
+}
+
+// SnapRaceSummary is the full self-serve analytics payload: global
+// reaction-time percentiles plus a per-bucket fairness breakdown.
+type SnapRaceSummary struct {
+	Attempts          int                  `json:"attempts"`
+	P50ReactionTimeMs int                  `json:"p50_reaction_time_ms"`
+	P90ReactionTimeMs int                  `json:"p90_reaction_time_ms"`
+	P99ReactionTimeMs int                  `json:"p99_reaction_time_ms"`
+	ByLatencyBucket   []LatencyBucketStats `json:"by_latency_bucket"`
+}
+
+// SummarizeSnapRace computes global reaction-time percentiles and
+// per-latency-bucket win rates from raw SnapEvents.
+func SummarizeSnapRace(events []SnapEvent) SnapRaceSummary {
+	if len(events) == 0 {
+		return SnapRaceSummary{}
+	}
+
+	times := make([]int, len(events))
+	for i, e := range events {
+		times[i] = e.ReactionTimeMs
+	}
+	sort.Ints(times)
+
+	buckets := make(map[string]*LatencyBucketStats)
+	var order []string
+	for _, e := range events {
+		b, ok := buckets[e.LatencyBucket]
+		if !ok {
+			b = &LatencyBucketStats{Bucket: e.LatencyBucket}
+			buckets[e.LatencyBucket] = b
+			order = append(order, e.LatencyBucket)
+		}
+		b.Attempts++
+		if e.Won {
+			b.Wins++
+		}
+	}
+	sort.Strings(order)
+
+	out := make([]LatencyBucketStats, 0, len(order))
+	for _, bucket := range order {
+		b := buckets[bucket]
+		b.WinRate = float64(b.Wins) / float64(b.Attempts)
+		out = append(out, *b)
+	}
+
+	return SnapRaceSummary{
+		Attempts:          len(events),
+		P50ReactionTimeMs: percentile(times, 50),
+		P90ReactionTimeMs: percentile(times, 90),
+		P99ReactionTimeMs: percentile(times, 99),
+		ByLatencyBucket:   out,
+	}
+}
+
+// percentile returns the pth percentile of a sorted slice via nearest-rank.
+func percentile(sorted []int, p int) int {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (p*len(sorted)+99)/100 - 1
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
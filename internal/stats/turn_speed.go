@@ -0,0 +1,55 @@
+package stats
+
+// TurnSpeedTracker accumulates how long a player takes per turn across
+// games, so matchmaking can group players with similar pace (fast players
+// hate waiting on slow ones, and vice versa).
+type TurnSpeedTracker struct {
+	samples map[string][]float64 // userID -> turn durations in seconds
+}
+
+// NewTurnSpeedTracker returns an empty tracker.
+func NewTurnSpeedTracker() *TurnSpeedTracker {
+	return &TurnSpeedTracker{samples: make(map[string][]float64)}
+}
+
+// Record adds a completed turn's duration for userID.
+func (t *TurnSpeedTracker) Record(userID string, seconds float64) {
+	t.samples[userID] = append(t.samples[userID], seconds)
+}
+
+// AverageSeconds returns the mean turn duration recorded for userID, or 0
+// if no samples exist yet.
+func (t *TurnSpeedTracker) AverageSeconds(userID string) float64 {
+	samples := t.samples[userID]
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range samples {
+		sum += s
+	}
+	return sum / float64(len(samples))
+}
+
+// SpeedBucket buckets a player's average turn time into a coarse
+// matchmaking preference tier.
+type SpeedBucket string
+
+const (
+	SpeedFast   SpeedBucket = "fast"
+	SpeedMedium SpeedBucket = "medium"
+	SpeedSlow   SpeedBucket = "slow"
+)
+
+// Bucket classifies userID's average turn speed for matchmaking grouping.
+func (t *TurnSpeedTracker) Bucket(userID string) SpeedBucket {
+	avg := t.AverageSeconds(userID)
+	switch {
+	case avg == 0, avg <= 5:
+		return SpeedFast
+	case avg <= 15:
+		return SpeedMedium
+	default:
+		return SpeedSlow
+	}
+}
@@ -0,0 +1,54 @@
+// Package store holds the in-memory registries the server keeps for live
+// games and other hot-path lookups.
+package store
+
+import (
+	"sync"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+)
+
+// GameStore is a concurrent-safe registry of in-progress games, keyed by ID.
+type GameStore struct {
+	mu    sync.RWMutex
+	games map[string]*game.Game
+}
+
+// NewGameStore returns an empty GameStore.
+func NewGameStore() *GameStore {
+	return &GameStore{games: make(map[string]*game.Game)}
+}
+
+// Get returns the game for id, if any.
+func (s *GameStore) Get(id string) (*game.Game, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	g, ok := s.games[id]
+	return g, ok
+}
+
+// Put registers a game under its own ID.
+func (s *GameStore) Put(g *game.Game) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.games[g.ID] = g
+}
+
+// All returns a snapshot of every currently registered game.
+func (s *GameStore) All() []*game.Game {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*game.Game, 0, len(s.games))
+	for _, g := range s.games {
+		out = append(out, g)
+	}
+	return out
+}
+
+// Delete removes a game from the registry, e.g. once it has finished and
+// been fully persisted.
+func (s *GameStore) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.games, id)
+}
@@ -0,0 +1,27 @@
+package store
+
+import "github.com/jason-s-yu/cambia-service/internal/game"
+
+// ForEach calls fn for every currently registered game while holding a
+// read lock, avoiding the allocation All() does for callers (like metrics
+// collection) that run frequently and don't need a snapshot slice. fn must
+// not call back into the GameStore, or it will deadlock.
+func (s *GameStore) ForEach(fn func(*game.Game)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, g := range s.games {
+		fn(g)
+	}
+}
+
+// CountByStatus returns how many games are currently in each Status, for
+// admin dashboards and metrics.
+func (s *GameStore) CountByStatus() map[game.Status]int {
+	counts := make(map[game.Status]int)
+	s.ForEach(func(g *game.Game) {
+		g.Mu.Lock()
+		counts[g.Status]++
+		g.Mu.Unlock()
+	})
+	return counts
+}
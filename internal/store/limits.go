@@ -0,0 +1,29 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+)
+
+// ErrAtCapacity is returned by GameStore.PutIfUnderCapacity when the server is already
+// running the maximum number of concurrent games.
+var ErrAtCapacity = errors.New("store: at max concurrent game capacity")
+
+// maxConcurrentGames bounds how many games this instance will run at once,
+// so a traffic spike degrades into "try again" responses instead of
+// unbounded goroutines and timers competing for the CPU.
+const maxConcurrentGames = 500
+
+// PutIfUnderCapacity registers a game unless the store is already at
+// maxConcurrentGames, in which case it returns ErrAtCapacity and leaves the
+// store unchanged.
+func (s *GameStore) PutIfUnderCapacity(g *game.Game) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.games) >= maxConcurrentGames {
+		return ErrAtCapacity
+	}
+	s.games[g.ID] = g
+	return nil
+}
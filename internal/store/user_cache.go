@@ -0,0 +1,47 @@
+package store
+
+import (
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/cache"
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// userCacheTTL bounds how long a user lookup result is reused before
+// re-checking the backing store. Short because the backing store here is
+// itself in-memory and cheap; this mostly pays off once UserStore is backed
+// by a real database.
+const userCacheTTL = 30 * time.Second
+
+// CachedUserStore wraps a UserStore with a TTL cache for Get, reducing
+// repeated lookups for hot users (e.g. the current player in every action
+// of a busy game) without changing UserStore's own concurrency behavior.
+type CachedUserStore struct {
+	*UserStore
+	cache *cache.TTLCache[string, *models.User]
+}
+
+// NewCachedUserStore wraps store with a TTL cache for Get lookups.
+func NewCachedUserStore(store *UserStore) *CachedUserStore {
+	return &CachedUserStore{UserStore: store, cache: cache.NewTTLCache[string, *models.User](userCacheTTL)}
+}
+
+// Get returns the user for id, preferring the cache and falling back to
+// the underlying UserStore on a miss.
+func (s *CachedUserStore) Get(id string) (*models.User, bool) {
+	if u, ok := s.cache.Get(id); ok {
+		return u, true
+	}
+	u, ok := s.UserStore.Get(id)
+	if ok {
+		s.cache.Set(id, u)
+	}
+	return u, ok
+}
+
+// Put writes through to the underlying UserStore and invalidates any stale
+// cache entry for the same ID.
+func (s *CachedUserStore) Put(u *models.User) {
+	s.UserStore.Put(u)
+	s.cache.Invalidate(u.ID)
+}
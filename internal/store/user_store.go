@@ -0,0 +1,51 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// UserStore is a concurrent-safe registry of known users, keyed by ID and
+// by username for uniqueness checks.
+type UserStore struct {
+	mu         sync.RWMutex
+	byID       map[string]*models.User
+	byUsername map[string]string // lowercased username -> userID
+}
+
+// NewUserStore returns an empty UserStore.
+func NewUserStore() *UserStore {
+	return &UserStore{
+		byID:       make(map[string]*models.User),
+		byUsername: make(map[string]string),
+	}
+}
+
+// Get returns the user for id, if known.
+func (s *UserStore) Get(id string) (*models.User, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	u, ok := s.byID[id]
+	return u, ok
+}
+
+// Put registers or replaces a user.
+func (s *UserStore) Put(u *models.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byID[u.ID] = u
+}
+
+// All returns every known user, for scans like digest.RunWeeklyScheduler
+// that need to check a preference across the whole registry rather than
+// looking one up by ID.
+func (s *UserStore) All() []*models.User {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*models.User, 0, len(s.byID))
+	for _, u := range s.byID {
+		out = append(out, u)
+	}
+	return out
+}
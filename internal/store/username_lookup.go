@@ -0,0 +1,13 @@
+package store
+
+import "strings"
+
+// ResolveUsername returns the userID claimed for username, if any. It
+// backs friend import and other username-to-ID lookups that shouldn't need
+// to know about the store's internal indexing.
+func (s *UserStore) ResolveUsername(username string) (userID string, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	id, found := s.byUsername[strings.ToLower(username)]
+	return id, found
+}
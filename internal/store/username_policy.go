@@ -0,0 +1,47 @@
+package store
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUsernameTaken and ErrUsernameReserved are returned by
+// UserStore.ClaimUsername when a name can't be assigned.
+var (
+	ErrUsernameTaken    = errors.New("store: username already taken")
+	ErrUsernameReserved = errors.New("store: username is reserved")
+)
+
+// reservedUsernames can't be claimed by any player: they're either
+// server-internal identities or reserved for impersonation-sensitive roles
+// (staff, system messages, etc.).
+var reservedUsernames = map[string]bool{
+	"admin":     true,
+	"moderator": true,
+	"system":    true,
+	"cambia":    true,
+	"server":    true,
+	"support":   true,
+}
+
+// ClaimUsername assigns username to userID if it's neither reserved nor
+// already taken by a different user, recording the claim for future
+// uniqueness checks. Comparison is case-insensitive so "Admin" and "admin"
+// can't coexist.
+func (s *UserStore) ClaimUsername(userID, username string) error {
+	key := strings.ToLower(username)
+	if reservedUsernames[key] {
+		return ErrUsernameReserved
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.byUsername[key]; ok && existing != userID {
+		return ErrUsernameTaken
+	}
+	s.byUsername[key] = userID
+	if u, ok := s.byID[userID]; ok {
+		u.Username = username
+	}
+	return nil
+}
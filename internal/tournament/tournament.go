@@ -0,0 +1,187 @@
+// Package tournament manages scheduled tournaments: their discovery feed
+// and round-by-round standings (driven by ws.RunCircuitScheduler).
+package tournament
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Tournament is a scheduled, multi-round competitive event players can
+// discover and register for ahead of time.
+type Tournament struct {
+	mu sync.Mutex
+
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	StartsAt   time.Time `json:"starts_at"`
+	MaxSeats   int       `json:"max_seats"`
+	Registered []string  `json:"registered"`
+
+	// TargetScore eliminates a player once their cumulative score across
+	// rounds reaches it, 0 meaning no elimination (every round just feeds
+	// the standings). Lower is better, matching Cambia's own scoring, so a
+	// higher cumulative score is worse.
+	TargetScore int `json:"target_score,omitempty"`
+	// CurrentRound counts completed rounds.
+	CurrentRound int `json:"current_round"`
+	// CurrentGameID is the in-progress round's game, empty between rounds.
+	CurrentGameID string `json:"current_game_id,omitempty"`
+	// Cumulative is each registered player's running total score.
+	Cumulative map[string]int `json:"cumulative,omitempty"`
+	// Eliminated marks players who've crossed TargetScore and no longer
+	// play in future rounds.
+	Eliminated map[string]bool `json:"eliminated,omitempty"`
+}
+
+// Standing is one player's position in a Tournament's running standings.
+type Standing struct {
+	UserID     string `json:"user_id"`
+	Score      int    `json:"score"`
+	Eliminated bool   `json:"eliminated"`
+}
+
+// RecordRound folds one round's final scores into the tournament's running
+// totals, eliminating anyone whose cumulative score now meets or exceeds
+// TargetScore (if set), and returns the userIDs newly eliminated this
+// round.
+func (t *Tournament) RecordRound(scores map[string]int) (eliminated []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Cumulative == nil {
+		t.Cumulative = make(map[string]int)
+	}
+	if t.Eliminated == nil {
+		t.Eliminated = make(map[string]bool)
+	}
+	for userID, score := range scores {
+		t.Cumulative[userID] += score
+	}
+	t.CurrentRound++
+	t.CurrentGameID = ""
+	if t.TargetScore <= 0 {
+		return nil
+	}
+	for userID, total := range t.Cumulative {
+		if !t.Eliminated[userID] && total >= t.TargetScore {
+			t.Eliminated[userID] = true
+			eliminated = append(eliminated, userID)
+		}
+	}
+	return eliminated
+}
+
+// CurrentGame returns the in-progress round's game ID, or "" between
+// rounds.
+func (t *Tournament) CurrentGame() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.CurrentGameID
+}
+
+// SetCurrentGame records the game ID for a newly started round.
+func (t *Tournament) SetCurrentGame(gameID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.CurrentGameID = gameID
+}
+
+// ActivePlayers returns Registered players who haven't been eliminated.
+func (t *Tournament) ActivePlayers() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]string, 0, len(t.Registered))
+	for _, userID := range t.Registered {
+		if !t.Eliminated[userID] {
+			out = append(out, userID)
+		}
+	}
+	return out
+}
+
+// Done reports whether the tournament has been decided: at most one
+// active player remains. A tournament with no TargetScore configured is
+// never Done this way and runs until something else ends it.
+func (t *Tournament) Done() bool {
+	t.mu.Lock()
+	targetScore := t.TargetScore
+	t.mu.Unlock()
+	return targetScore > 0 && len(t.ActivePlayers()) <= 1
+}
+
+// Standings returns every registered player's current standing, sorted by
+// cumulative score ascending (lower is better), ties broken by userID so
+// results are deterministic.
+func (t *Tournament) Standings() []Standing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]Standing, 0, len(t.Registered))
+	for _, userID := range t.Registered {
+		out = append(out, Standing{
+			UserID:     userID,
+			Score:      t.Cumulative[userID],
+			Eliminated: t.Eliminated[userID],
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score < out[j].Score
+		}
+		return out[i].UserID < out[j].UserID
+	})
+	return out
+}
+
+// Store holds upcoming and past tournaments in memory.
+type Store struct {
+	mu          sync.RWMutex
+	tournaments map[string]*Tournament
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{tournaments: make(map[string]*Tournament)}
+}
+
+// Add registers a tournament in the store.
+func (s *Store) Add(t *Tournament) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tournaments[t.ID] = t
+}
+
+// Get returns the tournament with the given ID, if any.
+func (s *Store) Get(id string) (*Tournament, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.tournaments[id]
+	return t, ok
+}
+
+// All returns every tournament in the store, in no particular order, for
+// the round-orchestration scheduler to scan.
+func (s *Store) All() []*Tournament {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*Tournament, 0, len(s.tournaments))
+	for _, t := range s.tournaments {
+		out = append(out, t)
+	}
+	return out
+}
+
+// Upcoming returns every tournament starting after now, soonest first,
+// suitable for a discovery feed.
+func (s *Store) Upcoming(now time.Time) []*Tournament {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []*Tournament
+	for _, t := range s.tournaments {
+		if t.StartsAt.After(now) {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartsAt.Before(out[j].StartsAt) })
+	return out
+}
@@ -0,0 +1,49 @@
+// Package webhook delivers GameEvents to external HTTP consumers, notably
+// the anti-cheat ML pipeline, which needs a live feed rather than having to
+// poll the historian.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+)
+
+// deliveryTimeout bounds how long we wait on a single webhook POST, so a
+// slow or dead consumer can't back up event delivery for everyone else.
+const deliveryTimeout = 5 * time.Second
+
+// Sink delivers GameEvents to a configured URL via HTTP POST. Delivery is
+// fire-and-forget: a failed delivery is logged, not retried, since losing
+// one anti-cheat sample isn't worth blocking the game loop over.
+type Sink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewSink returns a Sink posting to url with a bounded-timeout client.
+func NewSink(url string) *Sink {
+	return &Sink{URL: url, Client: &http.Client{Timeout: deliveryTimeout}}
+}
+
+// Deliver posts a single GameEvent to the sink's URL in a new goroutine so
+// callers never block on network I/O.
+func (s *Sink) Deliver(e historian.GameEvent) {
+	go func() {
+		body, err := json.Marshal(e)
+		if err != nil {
+			log.Printf("webhook: failed to marshal event: %v", err)
+			return
+		}
+		resp, err := s.Client.Post(s.URL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Printf("webhook: delivery to %s failed: %v", s.URL, err)
+			return
+		}
+		resp.Body.Close()
+	}()
+}
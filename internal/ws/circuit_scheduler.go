@@ -0,0 +1,156 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/circuit"
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+	"github.com/jason-s-yu/cambia-service/internal/models"
+	"github.com/jason-s-yu/cambia-service/internal/rating"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+	"github.com/jason-s-yu/cambia-service/internal/tournament"
+)
+
+// circuitTickInterval is how often the scheduler checks a tournament's
+// current-round game for completion.
+const circuitTickInterval = 5 * time.Second
+
+// circuitStandingsEvent is broadcast to a round's table when it ends, so
+// clients can show the updated leaderboard without a separate poll.
+type circuitStandingsEvent struct {
+	Type         string                `json:"type"`
+	TournamentID string                `json:"tournament_id"`
+	Standings    []tournament.Standing `json:"standings"`
+	Done         bool                  `json:"done"`
+	// RatingDeltas is each player's rating.ModeCircuit change from this
+	// round, nil if ratings is nil (circuit rounds don't require rating
+	// tracking to run).
+	RatingDeltas map[string]float64 `json:"rating_deltas,omitempty"`
+}
+
+// RunCircuitScheduler polls each tournament's in-progress round for
+// completion, folds its final scores into the tournament's standings, and
+// either starts the next round or finalizes the tournament once Done.
+// startNextRound is responsible for actually building and registering a
+// new game for the given players (lobby setup, seating, etc.) — this
+// scheduler only decides when that should happen.
+func RunCircuitScheduler(
+	hub *Hub,
+	games *store.GameStore,
+	hist *historian.Historian,
+	tournaments *tournament.Store,
+	ratings *rating.Store,
+	startNextRound func(t *tournament.Tournament, players []string) (gameID string, ok bool),
+) {
+	ticker := time.NewTicker(circuitTickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, t := range tournaments.All() {
+			gameID := t.CurrentGame()
+			if gameID == "" {
+				continue
+			}
+			g, ok := games.Get(gameID)
+			if !ok {
+				continue
+			}
+
+			g.Mu.Lock()
+			terminal := g.Status.Terminal()
+			var scores map[string]int
+			callerID := ""
+			if terminal {
+				scores = finalScores(g, hist)
+				if g.Cambia != nil {
+					callerID = g.Cambia.CallerID
+				}
+			}
+			g.Mu.Unlock()
+			if !terminal {
+				continue
+			}
+
+			t.RecordRound(scores)
+
+			var deltas map[string]float64
+			if ratings != nil {
+				deltas = rating.FinalizeRatings(ratings, rating.ModeCircuit, scores)
+			}
+
+			hist.Record(gameID, historian.EventGameEnded, map[string]interface{}{
+				"tournament_id": t.ID,
+				"scores":        scores,
+				"caller_id":     callerID,
+				"rating_deltas": deltas,
+			})
+
+			done := t.Done()
+			if payload, err := json.Marshal(circuitStandingsEvent{
+				Type:         "circuit_standings",
+				TournamentID: t.ID,
+				Standings:    t.Standings(),
+				Done:         done,
+				RatingDeltas: deltas,
+			}); err == nil {
+				hub.Broadcast(gameID, payload)
+			}
+
+			if done {
+				continue
+			}
+			if next, ok := startNextRound(t, t.ActivePlayers()); ok {
+				t.SetCurrentGame(next)
+			}
+		}
+	}
+}
+
+// finalScores computes each player's HandScoreWithConfig from g's current
+// hands, for folding into a circuit's cumulative standings once the round
+// has ended. A forfeited player (resigned or vote-kicked) is scored via
+// Game.FinalScore instead, and omitted entirely if HouseRules.ResignPenalty
+// is unset. If g.CircuitRules configures PointAdjustments, each player's
+// score is further adjusted per roundTriggers, derived from hist's action
+// log for this game. Caller must hold g.Mu.
+func finalScores(g *game.Game, hist *historian.Historian) map[string]int {
+	var snapWinners map[string]bool
+	if g.CircuitRules != nil && len(g.CircuitRules.PointAdjustments) > 0 {
+		snapWinners = make(map[string]bool)
+		for _, e := range hist.Log(g.ID) {
+			if e.Type != historian.EventSnapAttempt {
+				continue
+			}
+			userID, _ := e.Payload["user_id"].(string)
+			won, _ := e.Payload["won"].(bool)
+			if won {
+				snapWinners[userID] = true
+			}
+		}
+	}
+
+	scores := make(map[string]int, len(g.Players))
+	for _, p := range g.Players {
+		hand := make([]models.Card, 0, len(p.Hand))
+		for _, cardID := range p.Hand {
+			if c, ok := g.Cards[cardID]; ok {
+				hand = append(hand, c)
+			}
+		}
+		raw := game.HandScoreWithConfig(hand, g.HouseRules.Deck)
+		score, ok := g.FinalScore(p.UserID, raw)
+		if !ok {
+			continue
+		}
+		if g.CircuitRules != nil && len(g.CircuitRules.PointAdjustments) > 0 {
+			score = g.CircuitRules.Evaluate(score, map[circuit.AdjustmentTrigger]bool{
+				circuit.TriggerSnapWin: snapWinners[p.UserID],
+				circuit.TriggerNudged:  g.NudgeCounts[p.UserID] > 0,
+			})
+		}
+		scores[p.UserID] = score
+	}
+	return scores
+}
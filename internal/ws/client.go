@@ -0,0 +1,284 @@
+// Package ws manages websocket connections for in-progress games: the
+// per-connection read/write pumps and the hub that fans out broadcasts to
+// all clients seated at a game.
+package ws
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = (pongWait * 9) / 10
+
+	// maxMessageBytes bounds a single inbound frame. Game actions are small
+	// JSON payloads; anything beyond this is either a bug or a client trying
+	// to exhaust server memory via oversized frames.
+	maxMessageBytes = 32 * 1024
+
+	// maxSeenActionIDs bounds Client's per-connection action-id dedupe
+	// cache so a client sending many distinct ids can't grow it
+	// unbounded; oldest ids are evicted first.
+	maxSeenActionIDs = 256
+)
+
+// Upgrader is shared across all game connections. EnableCompression
+// negotiates permessage-deflate with clients that support it; gorilla falls
+// back to uncompressed frames transparently when they don't.
+var Upgrader = websocket.Upgrader{
+	ReadBufferSize:    4096,
+	WriteBufferSize:   4096,
+	EnableCompression: true,
+	CheckOrigin:       func(r *http.Request) bool { return true },
+	Subprotocols:      SupportedProtocolStrings(),
+}
+
+// Client represents one player's live connection to a game.
+type Client struct {
+	GameID string
+	UserID string
+	Conn   *websocket.Conn
+	Send   chan []byte
+	Hub    *Hub
+
+	// ProtocolVersion is the schema version negotiated at Upgrade time
+	// (see Upgrader.Subprotocols); ProtocolV1 for a legacy client that
+	// never requested one.
+	ProtocolVersion ProtocolVersion
+
+	drops dropTracker
+
+	// quiet is this connection's do-not-disturb flag: set true, it opts
+	// out of non-essential pushes (chat, lobby crowd updates, emotes)
+	// while still receiving turn-critical events, which always go through
+	// Hub.Broadcast rather than Hub.BroadcastNonEssential.
+	quiet atomic.Bool
+
+	// dormant is set true when a mobile client reports it's backgrounded.
+	// Like quiet, it opts the connection out of non-essential broadcasts;
+	// unlike quiet, it's also visible to the game layer (see
+	// game.Game.SetDormant) so a backgrounded player's turn timer gets an
+	// extension instead of timing out while they're away from the app.
+	dormant atomic.Bool
+
+	actionIDsMu   sync.Mutex
+	seenActionIDs map[string]bool
+	actionIDOrder []string // FIFO eviction order, parallel to seenActionIDs
+
+	// pingSentAtNano and rttMs implement round-trip latency measurement
+	// off the existing keepalive ping/pong (see writePump/readPump):
+	// pingSentAtNano records when the last ping went out, and rttMs is
+	// set from the elapsed time once its pong comes back.
+	pingSentAtNano atomic.Int64
+	rttMs          atomic.Int64
+
+	// resyncs counts how many times this connection needed an
+	// EventsSince catch-up (see RecordResync) rather than resuming from
+	// live state alone, the per-connection counterpart to
+	// game.Audit.DesyncResyncs.
+	resyncs atomic.Int32
+
+	// lastAckedEventID is the highest historian event ID this connection
+	// has confirmed receiving, set from its last_event_id resume query
+	// param on connect.
+	lastAckedEventID atomic.Int64
+}
+
+// SetQuiet toggles this connection's do-not-disturb flag.
+func (c *Client) SetQuiet(quiet bool) {
+	c.quiet.Store(quiet)
+}
+
+// IsQuiet reports whether this connection currently has quiet mode on.
+func (c *Client) IsQuiet() bool {
+	return c.quiet.Load()
+}
+
+// SetDormant toggles this connection's backgrounded flag.
+func (c *Client) SetDormant(dormant bool) {
+	c.dormant.Store(dormant)
+}
+
+// IsDormant reports whether this connection is currently backgrounded.
+func (c *Client) IsDormant() bool {
+	return c.dormant.Load()
+}
+
+// SeenActionID records id as processed and reports whether it was already
+// seen on this connection, so a handler can skip re-applying an action a
+// client retried after a transient error instead of double-applying it
+// (e.g. double-discarding or double-snapping). An empty id is never
+// deduped, since it means the client isn't using action sequencing.
+func (c *Client) SeenActionID(id string) (duplicate bool) {
+	if id == "" {
+		return false
+	}
+	c.actionIDsMu.Lock()
+	defer c.actionIDsMu.Unlock()
+	if c.seenActionIDs == nil {
+		c.seenActionIDs = make(map[string]bool)
+	}
+	if c.seenActionIDs[id] {
+		return true
+	}
+	c.seenActionIDs[id] = true
+	c.actionIDOrder = append(c.actionIDOrder, id)
+	if len(c.actionIDOrder) > maxSeenActionIDs {
+		oldest := c.actionIDOrder[0]
+		c.actionIDOrder = c.actionIDOrder[1:]
+		delete(c.seenActionIDs, oldest)
+	}
+	return false
+}
+
+// RecordPing notes that a keepalive ping was just sent, as the start point
+// for the next RTT measurement.
+func (c *Client) RecordPing() {
+	c.pingSentAtNano.Store(time.Now().UnixNano())
+}
+
+// RecordPong completes an RTT measurement from the matching RecordPing, a
+// no-op if no ping is outstanding.
+func (c *Client) RecordPong() {
+	sent := c.pingSentAtNano.Load()
+	if sent == 0 {
+		return
+	}
+	c.rttMs.Store(time.Since(time.Unix(0, sent)).Milliseconds())
+}
+
+// RTTMillis returns the most recently measured ping/pong round-trip time
+// in milliseconds, or 0 if none has completed yet.
+func (c *Client) RTTMillis() int64 {
+	return c.rttMs.Load()
+}
+
+// RecordResync notes that this connection needed an EventsSince catch-up
+// on (re)connect rather than resuming from live state alone.
+func (c *Client) RecordResync() {
+	c.resyncs.Add(1)
+}
+
+// ResyncCount returns how many times this connection has needed an
+// EventsSince catch-up.
+func (c *Client) ResyncCount() int {
+	return int(c.resyncs.Load())
+}
+
+// SetLastAckedEventID records the highest historian event ID this
+// connection has confirmed receiving.
+func (c *Client) SetLastAckedEventID(id int) {
+	c.lastAckedEventID.Store(int64(id))
+}
+
+// LastAckedEventID returns the highest historian event ID this connection
+// has confirmed receiving, or 0 if it never reported one.
+func (c *Client) LastAckedEventID() int {
+	return int(c.lastAckedEventID.Load())
+}
+
+// IsSlow reports whether this client has recently missed enough broadcasts
+// in a row that the hub should consider coalescing its updates instead of
+// continuing to drop them outright.
+func (c *Client) IsSlow() bool {
+	return c.drops.consecutiveDrops >= slowClientDropThreshold
+}
+
+// TotalDrops reports how many broadcasts this connection has missed over
+// its entire lifetime, for a post-game fairness audit.
+func (c *Client) TotalDrops() int {
+	return c.drops.totalDrops
+}
+
+// NewClient wraps an upgraded connection for a given game/user pair. Its
+// ProtocolVersion is whatever conn.Subprotocol() negotiated during
+// Upgrade, defaulting to ProtocolV1 for a legacy client that didn't
+// request one at all.
+func NewClient(hub *Hub, gameID, userID string, conn *websocket.Conn) *Client {
+	conn.SetReadLimit(maxMessageBytes)
+	version := ProtocolVersion(conn.Subprotocol())
+	if version == "" {
+		version = ProtocolV1
+	}
+	return &Client{
+		GameID:          gameID,
+		UserID:          userID,
+		Conn:            conn,
+		Send:            make(chan []byte, 16),
+		Hub:             hub,
+		ProtocolVersion: version,
+	}
+}
+
+// readPump reads inbound messages from the client and forwards them to the
+// game's message handler until the connection closes.
+func (c *Client) readPump(handle func(*Client, []byte)) {
+	defer c.Hub.unregister(c)
+	c.Conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.Conn.SetPongHandler(func(string) error {
+		c.Conn.SetReadDeadline(time.Now().Add(pongWait))
+		c.RecordPong()
+		return nil
+	})
+	for {
+		_, msg, err := c.Conn.ReadMessage()
+		if err != nil {
+			if isOversizedFrame(err) {
+				c.closeWithPolicyViolation("message too large")
+			} else if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				log.Printf("ws: unexpected close for user %s in game %s: %v", c.UserID, c.GameID, err)
+			}
+			return
+		}
+		handle(c, msg)
+	}
+}
+
+// isOversizedFrame reports whether err came from gorilla's read-limit
+// enforcement (SetReadLimit), as opposed to an ordinary disconnect.
+func isOversizedFrame(err error) bool {
+	return err != nil && err.Error() == "websocket: read limit exceeded"
+}
+
+// closeWithPolicyViolation sends a CloseMessage with code 1008
+// (policy violation) so well-behaved clients can distinguish "you sent
+// something we refuse to process" from a generic disconnect.
+func (c *Client) closeWithPolicyViolation(reason string) {
+	c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+	msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason)
+	c.Conn.WriteMessage(websocket.CloseMessage, msg)
+}
+
+// writePump drains Send and forwards keepalive pings until the connection
+// closes.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+	defer c.Conn.Close()
+	for {
+		select {
+		case msg, ok := <-c.Send:
+			if !ok {
+				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.Conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+			c.RecordPing()
+			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
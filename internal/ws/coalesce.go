@@ -0,0 +1,86 @@
+package ws
+
+import "time"
+
+// slowClientDropThreshold is how many consecutive dropped sends (Hub.send's
+// default branch, a full buffer) mark a client as slow and switch it to
+// coalesced delivery instead of per-event delivery.
+const slowClientDropThreshold = 5
+
+// coalesceWindow is how long a slow client's updates are batched before
+// being flushed as one message, trading latency for throughput once a
+// client can't keep up with per-event delivery.
+const coalesceWindow = 250 * time.Millisecond
+
+// dropTracker counts consecutive dropped sends per client so Hub.Broadcast
+// can detect a client falling behind without adding per-message overhead
+// for the common case of a healthy connection. totalDrops additionally
+// accumulates across the whole connection lifetime, independent of
+// consecutiveDrops resetting on every successful send, for the per-player
+// dropped-message count in a post-game fairness audit.
+type dropTracker struct {
+	consecutiveDrops int
+	totalDrops       int
+}
+
+// recordDrop increments the counter and reports whether the client has now
+// crossed the slow-client threshold.
+func (d *dropTracker) recordDrop() (isSlow bool) {
+	d.consecutiveDrops++
+	d.totalDrops++
+	return d.consecutiveDrops >= slowClientDropThreshold
+}
+
+// recordSuccess resets the counter after a successful send.
+func (d *dropTracker) recordSuccess() {
+	d.consecutiveDrops = 0
+}
+
+// Coalescer batches messages for a slow client and hands each batch to its
+// flush callback every coalesceWindow, rather than relying on per-message
+// channel sends that the client can't keep up with.
+type Coalescer struct {
+	pending chan []byte
+	flush   func([][]byte)
+}
+
+// NewCoalescer starts a background goroutine that batches incoming
+// messages and calls flush with each batch every coalesceWindow.
+func NewCoalescer(flush func([][]byte)) *Coalescer {
+	c := &Coalescer{pending: make(chan []byte, 256), flush: flush}
+	go c.run()
+	return c
+}
+
+func (c *Coalescer) run() {
+	ticker := time.NewTicker(coalesceWindow)
+	defer ticker.Stop()
+	var batch [][]byte
+	for {
+		select {
+		case msg, ok := <-c.pending:
+			if !ok {
+				if len(batch) > 0 {
+					c.flush(batch)
+				}
+				return
+			}
+			batch = append(batch, msg)
+		case <-ticker.C:
+			if len(batch) > 0 {
+				c.flush(batch)
+				batch = nil
+			}
+		}
+	}
+}
+
+// Add queues msg for the next flush.
+func (c *Coalescer) Add(msg []byte) {
+	c.pending <- msg
+}
+
+// Close stops the coalescer after flushing any pending batch.
+func (c *Coalescer) Close() {
+	close(c.pending)
+}
@@ -0,0 +1,31 @@
+package ws
+
+import "encoding/json"
+
+// protocolShims rewrites action types from older protocol versions into
+// their current equivalents, so a blue/green deploy can roll a new server
+// version out gradually without breaking clients still on the old one.
+// Entries are removed once the corresponding client version has aged out.
+var protocolShims = map[string]string{
+	"draw_pile": "draw_stockpile", // renamed in protocol v2
+}
+
+// ApplyCompatShims rewrites env in place for known legacy action names,
+// returning the (possibly unmodified) raw bytes for the handler to
+// continue parsing normally.
+func ApplyCompatShims(raw []byte) []byte {
+	var env ActionEnvelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return raw
+	}
+	newType, shimmed := protocolShims[env.Type]
+	if !shimmed {
+		return raw
+	}
+	env.Type = newType
+	rewritten, err := json.Marshal(env)
+	if err != nil {
+		return raw
+	}
+	return rewritten
+}
@@ -0,0 +1,57 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// connectionStatsInterval is how often every connected client gets an
+// unsolicited connection_stats event; a client can also ask for one
+// on-demand via the request_connection_stats action.
+const connectionStatsInterval = 15 * time.Second
+
+// ConnectionStatsEvent is sent privately to one player with the server's
+// own view of their connection, so support can debug "lag" complaints
+// with real data instead of guesswork.
+type ConnectionStatsEvent struct {
+	Type             string `json:"type"`
+	GameID           string `json:"game_id"`
+	RTTMillis        int64  `json:"rtt_ms"`
+	DroppedMessages  int    `json:"dropped_messages"`
+	ResyncCount      int    `json:"resync_count"`
+	LastAckedEventID int    `json:"last_acked_event_id"`
+}
+
+// BuildConnectionStats assembles c's own connection_stats payload for
+// gameID.
+func BuildConnectionStats(gameID string, c *Client) ConnectionStatsEvent {
+	return ConnectionStatsEvent{
+		Type:             "connection_stats",
+		GameID:           gameID,
+		RTTMillis:        c.RTTMillis(),
+		DroppedMessages:  c.TotalDrops(),
+		ResyncCount:      c.ResyncCount(),
+		LastAckedEventID: c.LastAckedEventID(),
+	}
+}
+
+// RunConnectionStatsScheduler periodically sends every connected client
+// its own connection_stats event, built from this instance's view of
+// their Client. It blocks until the process exits and is meant to be
+// started once in a background goroutine.
+func RunConnectionStatsScheduler(hub *Hub, games *store.GameStore) {
+	ticker := time.NewTicker(connectionStatsInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, g := range games.All() {
+			for userID, stats := range hub.ConnectionStats(g.ID) {
+				if payload, err := json.Marshal(stats); err == nil {
+					hub.SendToUser(g.ID, userID, payload)
+				}
+			}
+		}
+	}
+}
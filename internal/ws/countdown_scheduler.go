@@ -0,0 +1,71 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/lobby"
+)
+
+// countdownSyncInterval is how often RunLobbyCountdownScheduler reconciles
+// lobby deadlines into the wheel. It only needs to be frequent enough to
+// notice a new/changed/canceled countdown promptly; wheelTick governs how
+// precisely the deadline itself fires.
+const countdownSyncInterval = 1 * time.Second
+
+// lobbyCountdownKey namespaces a lobby's wheel entry so it can't collide
+// with a turn-expiry entry (see RunTurnExpiryScheduler) even though both
+// schedulers share one TimerWheel.
+func lobbyCountdownKey(gameID string) string {
+	return "lobby_countdown:" + gameID
+}
+
+// lobbyCountdownCompleteEvent is broadcast when a lobby's start countdown
+// elapses, so clients know to expect the match to begin; it does not
+// itself deal cards or flip Game.Status — this repo has no "start the
+// match from a lobby" entry point to call yet (see api.LobbyHandlers), so
+// for now this is the honest scope: the scheduling half of "pre-game/
+// countdown expirations" the wheel exists to cover.
+type lobbyCountdownCompleteEvent struct {
+	Type   string `json:"type"`
+	GameID string `json:"game_id"`
+}
+
+// RunLobbyCountdownScheduler keeps wheel in sync with every open lobby's
+// countdown deadline, rescheduling whenever one starts, restarts, or is
+// canceled, so a countdown fires in wheelTick rather than
+// countdownSyncInterval once it's actually due.
+func RunLobbyCountdownScheduler(wheel *TimerWheel, hub *Hub, lobbies map[string]*lobby.Lobby) {
+	ticker := time.NewTicker(countdownSyncInterval)
+	defer ticker.Stop()
+
+	scheduled := make(map[string]time.Time) // gameID -> deadline last scheduled
+	for range ticker.C {
+		for gameID, l := range lobbies {
+			deadline, running := l.Deadline()
+			key := lobbyCountdownKey(gameID)
+			if !running {
+				if _, ok := scheduled[gameID]; ok {
+					wheel.Cancel(key)
+					delete(scheduled, gameID)
+				}
+				continue
+			}
+			if prev, ok := scheduled[gameID]; ok && prev.Equal(deadline) {
+				continue
+			}
+			scheduled[gameID] = deadline
+			wheel.Schedule(key, deadline, func() {
+				if d, running := l.Deadline(); !running || !d.Equal(deadline) {
+					return // canceled or restarted since this was scheduled
+				}
+				if payload, err := json.Marshal(lobbyCountdownCompleteEvent{
+					Type:   "lobby_countdown_complete",
+					GameID: gameID,
+				}); err == nil {
+					hub.Broadcast(gameID, payload)
+				}
+			})
+		}
+	}
+}
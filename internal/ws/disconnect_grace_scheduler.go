@@ -0,0 +1,81 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// disconnectGraceTickInterval is how often the scheduler checks frozen
+// seats for an expired HouseRules.DisconnectGraceSec window. It's shorter
+// than the ranked, circuit and length-cap schedulers' 5s ticks since a player waiting out
+// their own forfeiture cares about the window closing promptly once it's
+// actually up.
+const disconnectGraceTickInterval = 2 * time.Second
+
+// playerForfeitedEvent is broadcast to a game's table when a disconnect
+// grace window expires without the player reconnecting.
+type playerForfeitedEvent struct {
+	Type   string `json:"type"`
+	GameID string `json:"game_id"`
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+// RunDisconnectGraceScheduler polls every non-terminal game for seats
+// frozen past HouseRules.DisconnectGraceSec (see Game.OnDisconnect and
+// Game.DisconnectGraceExpired) and forfeits them via Game.ForfeitPlayer
+// once the window closes without a reconnect. Circuit-mode freezes are
+// left alone here: those resolve via circuit.Seat.AdvanceRound against
+// CircuitRules.MaxFrozenRounds instead of a wall-clock window, driven by
+// RunCircuitScheduler's round boundaries.
+func RunDisconnectGraceScheduler(hub *Hub, games *store.GameStore, hist *historian.Historian) {
+	ticker := time.NewTicker(disconnectGraceTickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		all := games.All()
+		offset := nextTickOffset(len(all))
+		checked := 0
+		for i := range all {
+			if checked >= maxGamesCheckedPerTick {
+				break
+			}
+			checked++
+			g := all[(offset+i)%len(all)]
+
+			g.Mu.Lock()
+			if g.Status.Terminal() {
+				g.Mu.Unlock()
+				continue
+			}
+			var expired []string
+			for userID := range g.Seats {
+				if g.DisconnectGraceExpired(userID) {
+					expired = append(expired, userID)
+				}
+			}
+			for _, userID := range expired {
+				g.ForfeitPlayer(userID)
+			}
+			gameID := g.ID
+			g.Mu.Unlock()
+
+			for _, userID := range expired {
+				hist.Record(gameID, historian.EventPlayerForfeitedDisconnect, map[string]interface{}{
+					"user_id": userID,
+				})
+				if payload, err := json.Marshal(playerForfeitedEvent{
+					Type:   "player_forfeited",
+					GameID: gameID,
+					UserID: userID,
+					Reason: "disconnect_grace_expired",
+				}); err == nil {
+					hub.Broadcast(gameID, payload)
+				}
+			}
+		}
+	}
+}
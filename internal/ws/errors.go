@@ -0,0 +1,53 @@
+package ws
+
+import (
+	"encoding/json"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+)
+
+// ErrorEnvelope is the structured failure response sent privately to the
+// client whose action was rejected. It replaces ad hoc free-text messages
+// (and, previously, some action handlers silently dropping a failed
+// action with no response at all) with a stable Code a client can branch
+// on — e.g. to decide whether retrying makes sense — without
+// string-matching Message, which is for logs/debugging only.
+type ErrorEnvelope struct {
+	Type      string         `json:"type"`
+	Code      game.ErrorCode `json:"code"`
+	Action    string         `json:"action,omitempty"`
+	Message   string         `json:"message,omitempty"`
+	Retryable bool           `json:"retryable,omitempty"`
+	// ActionID echoes back the rejected envelope's ActionEnvelope.ActionID,
+	// if the client sent one, so it can match this error to the retry it's
+	// deduping rather than guessing from Action alone.
+	ActionID string `json:"action_id,omitempty"`
+}
+
+// ErrMalformedPayload is used outside the game authorization matrix, for
+// an envelope whose Data didn't even unmarshal into the action's expected
+// shape.
+const ErrMalformedPayload game.ErrorCode = "ERR_MALFORMED_PAYLOAD"
+
+// ErrUnsupportedProtocolVersion is used when a connecting client requests
+// only Sec-WebSocket-Protocol versions this server no longer (or
+// doesn't yet) support; see SupportedProtocolVersions.
+const ErrUnsupportedProtocolVersion game.ErrorCode = "ERR_UNSUPPORTED_PROTOCOL_VERSION"
+
+// SendError formats and delivers an ErrorEnvelope to a single client,
+// never broadcasting the failure to the rest of the table.
+func SendError(hub *Hub, gameID, userID string, code game.ErrorCode, action, actionID, message string, retryable bool) {
+	payload, err := json.Marshal(ErrorEnvelope{
+		Type: "error", Code: code, Action: action, Message: message, Retryable: retryable, ActionID: actionID,
+	})
+	if err != nil {
+		return
+	}
+	hub.SendToUser(gameID, userID, payload)
+}
+
+// SendAuthorizeError is SendError specialized for a *game.AuthorizeError,
+// the common case of an action rejected by Game.Authorize.
+func SendAuthorizeError(hub *Hub, gameID, userID, actionID string, err *game.AuthorizeError) {
+	SendError(hub, gameID, userID, err.Code, string(err.Action), actionID, err.Message, false)
+}
@@ -0,0 +1,42 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+)
+
+// eventBatchEvent wraps one or more historian.GameEvents into a single WS
+// frame, so a client catching up on many missed events (e.g. a reconnect
+// replay, or a future burst of penalty-draw events) costs one frame instead
+// of one per event. Each wrapped event still carries its own Seq, so the
+// client can detect a gap even across a batch.
+type eventBatchEvent struct {
+	Type string `json:"type"`
+	// TurnID is the live Game.TurnCount at the moment the batch was built,
+	// letting a client place the batch within the game's turn sequence
+	// even though historian itself has no turn awareness.
+	TurnID int `json:"turn_id"`
+	// ServerTimestamp is when this batch was assembled and sent, distinct
+	// from each wrapped event's own Timestamp (when it was originally
+	// recorded), since a reconnect replay can batch events recorded long
+	// before the batch itself is sent.
+	ServerTimestamp time.Time             `json:"server_timestamp"`
+	Events          []historian.GameEvent `json:"events"`
+}
+
+// BuildEventBatch marshals events into a single event_batch frame, tagged
+// with turnID. It returns (nil, nil) for an empty batch, since there's
+// nothing useful to send a client that isn't missing anything.
+func BuildEventBatch(turnID int, now time.Time, events []historian.GameEvent) ([]byte, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(eventBatchEvent{
+		Type:            "event_batch",
+		TurnID:          turnID,
+		ServerTimestamp: now,
+		Events:          events,
+	})
+}
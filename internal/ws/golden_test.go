@@ -0,0 +1,38 @@
+package ws
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// TestTurnReminderGoldenFile pins the exact wire shape of turn_reminder
+// events. If this fails, either update testdata/turn_reminder.golden.json
+// deliberately (a protocol change) or fix the regression.
+func TestTurnReminderGoldenFile(t *testing.T) {
+	event := turnReminderEvent{Type: "turn_reminder", GameID: "g1", Remaining: 5}
+
+	got, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	want, err := os.ReadFile("testdata/turn_reminder.golden.json")
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+	}
+
+	var gotNorm, wantNorm interface{}
+	if err := json.Unmarshal(got, &gotNorm); err != nil {
+		t.Fatalf("unmarshal got: %v", err)
+	}
+	if err := json.Unmarshal(want, &wantNorm); err != nil {
+		t.Fatalf("unmarshal golden: %v", err)
+	}
+
+	gotJSON, _ := json.Marshal(gotNorm)
+	wantJSON, _ := json.Marshal(wantNorm)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("turn_reminder shape changed:\ngot:  %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,234 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/jason-s-yu/cambia-service/internal/broadcast"
+)
+
+// Hub fans broadcasts out to every client seated at a given game.
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[string]map[*Client]bool // gameID -> set of clients
+
+	// PubSub, if set, fans every delivery out across server instances
+	// instead of this process's local clients alone; see
+	// internal/broadcast. Nil means single-instance, local-only delivery.
+	PubSub broadcast.PubSub
+	subs   map[string]func() // gameID -> PubSub unsubscribe, one per actively-watched game
+
+	// OnDisconnect, if set, is called with (gameID, userID) whenever a
+	// client's connection drops, so the game layer can decide how to treat
+	// the disconnect (forfeit, freeze, etc.) without the hub knowing about
+	// game rules.
+	OnDisconnect func(gameID, userID string)
+
+	// OnDormantTurnApproaching, if set, is called with (gameID, userID)
+	// when a dormant (backgrounded) player's turn is approaching, so an
+	// external push-notification channel can wake their device. There's no
+	// APNs/FCM client in this codebase, so the hub only raises the hook;
+	// see its wiring in cmd/server/main.go for what actually happens with
+	// it today.
+	OnDormantTurnApproaching func(gameID, userID string)
+}
+
+// NewHub returns an empty Hub with local-only delivery (no PubSub).
+func NewHub() *Hub {
+	return &Hub{clients: make(map[string]map[*Client]bool)}
+}
+
+// hubEnvelope is what actually travels through PubSub: enough for a
+// receiving instance to re-derive which local clients a delivery call was
+// meant for, since PubSub itself only knows about opaque channel+payload.
+type hubEnvelope struct {
+	// UserID restricts delivery to one client, for SendToUser/
+	// SendToUserNonEssential. Empty means every client in the channel.
+	UserID string `json:"user_id,omitempty"`
+	// NonEssential marks a delivery that quiet-mode clients opt out of.
+	NonEssential bool   `json:"non_essential,omitempty"`
+	Payload      []byte `json:"payload"`
+}
+
+// ConnectedCount returns how many clients are currently connected to this
+// instance across every game, for the /metrics connected_websockets gauge.
+// It only counts this process's local connections, the same scope
+// everything else in Hub has before PubSub fans a delivery out.
+func (h *Hub) ConnectedCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	n := 0
+	for _, clients := range h.clients {
+		n += len(clients)
+	}
+	return n
+}
+
+func (h *Hub) register(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.clients[c.GameID] == nil {
+		h.clients[c.GameID] = make(map[*Client]bool)
+		h.subscribeLocked(c.GameID)
+	}
+	h.clients[c.GameID][c] = true
+}
+
+func (h *Hub) unregister(c *Client) {
+	h.mu.Lock()
+	delete(h.clients[c.GameID], c)
+	close(c.Send)
+	if len(h.clients[c.GameID]) == 0 {
+		delete(h.clients, c.GameID)
+		h.unsubscribeLocked(c.GameID)
+	}
+	h.mu.Unlock()
+
+	if h.OnDisconnect != nil {
+		h.OnDisconnect(c.GameID, c.UserID)
+	}
+}
+
+// subscribeLocked starts watching gameID's PubSub channel, if PubSub is
+// configured, so deliveries published by any instance (including this
+// one) reach this instance's locally-connected clients. Callers must hold
+// h.mu.
+func (h *Hub) subscribeLocked(gameID string) {
+	if h.PubSub == nil {
+		return
+	}
+	if h.subs == nil {
+		h.subs = make(map[string]func())
+	}
+	h.subs[gameID] = h.PubSub.Subscribe(gameID, func(m broadcast.Message) {
+		var env hubEnvelope
+		if err := json.Unmarshal(m.Payload, &env); err != nil {
+			return
+		}
+		h.deliverLocal(gameID, env)
+	})
+}
+
+// unsubscribeLocked stops watching gameID's PubSub channel once its last
+// local client has left. Callers must hold h.mu.
+func (h *Hub) unsubscribeLocked(gameID string) {
+	if unsub, ok := h.subs[gameID]; ok {
+		unsub()
+		delete(h.subs, gameID)
+	}
+}
+
+// publish routes env through PubSub if configured, falling back to local
+// delivery (including on a marshal failure, so a broken envelope never
+// means a silently dropped message) when it isn't.
+func (h *Hub) publish(gameID string, env hubEnvelope) {
+	if h.PubSub != nil {
+		if data, err := json.Marshal(env); err == nil {
+			h.PubSub.Publish(gameID, data)
+			return
+		}
+	}
+	h.deliverLocal(gameID, env)
+}
+
+// deliverLocal sends env.Payload to this instance's locally-connected
+// clients for gameID matching env's targeting, recording drops/successes
+// on each as normal.
+func (h *Hub) deliverLocal(gameID string, env hubEnvelope) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.clients[gameID] {
+		if env.UserID != "" && c.UserID != env.UserID {
+			continue
+		}
+		if env.NonEssential && (c.IsQuiet() || c.IsDormant()) {
+			continue
+		}
+		select {
+		case c.Send <- env.Payload:
+			c.drops.recordSuccess()
+		default:
+			c.drops.recordDrop()
+		}
+	}
+}
+
+// Broadcast sends msg to every client currently in gameID, on every
+// server instance if PubSub is configured. A client that repeatedly can't
+// keep up (its Send buffer is still full) is tracked via dropTracker so
+// callers can later check Client.IsSlow and switch it to coalesced
+// delivery instead of dropping every broadcast outright.
+func (h *Hub) Broadcast(gameID string, msg []byte) {
+	h.publish(gameID, hubEnvelope{Payload: msg})
+}
+
+// BroadcastNonEssential is Broadcast for pushes a player can opt out of
+// without missing anything that affects play: chat, lobby crowd-size
+// updates, emotes, announcements. Clients with quiet mode on are skipped
+// entirely, not counted as drops, since the non-delivery is intentional
+// rather than the client falling behind.
+func (h *Hub) BroadcastNonEssential(gameID string, msg []byte) {
+	h.publish(gameID, hubEnvelope{Payload: msg, NonEssential: true})
+}
+
+// SendToUser delivers msg only to userID's connection within gameID, e.g.
+// for private reminders that other players shouldn't see. userID may be
+// connected to a different server instance than this one if PubSub is
+// configured.
+func (h *Hub) SendToUser(gameID, userID string, msg []byte) {
+	h.publish(gameID, hubEnvelope{Payload: msg, UserID: userID})
+}
+
+// SendToUserNonEssential is SendToUser for a push userID can opt out of
+// via quiet mode; see BroadcastNonEssential.
+func (h *Hub) SendToUserNonEssential(gameID, userID string, msg []byte) {
+	h.publish(gameID, hubEnvelope{Payload: msg, UserID: userID, NonEssential: true})
+}
+
+// DropCounts returns each connected client's lifetime dropped-broadcast
+// count for gameID, keyed by userID, for a post-game fairness audit. This
+// only sees clients connected to this instance.
+func (h *Hub) DropCounts(gameID string) map[string]int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make(map[string]int, len(h.clients[gameID]))
+	for c := range h.clients[gameID] {
+		out[c.UserID] = c.TotalDrops()
+	}
+	return out
+}
+
+// ConnectionStats returns each connected client's current connection
+// diagnostics for gameID, keyed by userID, for RunConnectionStatsScheduler
+// and the request_connection_stats action. This only sees clients
+// connected to this instance.
+func (h *Hub) ConnectionStats(gameID string) map[string]ConnectionStatsEvent {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make(map[string]ConnectionStatsEvent, len(h.clients[gameID]))
+	for c := range h.clients[gameID] {
+		out[c.UserID] = BuildConnectionStats(gameID, c)
+	}
+	return out
+}
+
+// Serve registers the client, then blocks running its read/write pumps
+// until the connection closes.
+func (h *Hub) Serve(c *Client, handle func(*Client, []byte)) {
+	h.register(c)
+	go c.writePump()
+	c.readPump(recoverGameHandler(validatingHandler(handle)))
+}
+
+// validatingHandler rejects malformed envelopes before they reach handle,
+// so every action handler can assume a well-formed ActionEnvelope.
+func validatingHandler(handle func(*Client, []byte)) func(*Client, []byte) {
+	return func(c *Client, msg []byte) {
+		msg = ApplyCompatShims(msg)
+		if _, err := ValidateEnvelope(msg); err != nil {
+			c.closeWithPolicyViolation(err.Error())
+			return
+		}
+		handle(c, msg)
+	}
+}
@@ -0,0 +1,48 @@
+package ws
+
+import "testing"
+
+// BenchmarkBroadcast measures fan-out cost as the number of clients in a
+// single game grows, since Broadcast holds Hub.mu.RLock for the duration of
+// the fan-out and is on the hot path for every action taken.
+func BenchmarkBroadcast(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000} {
+		b.Run(benchName(n), func(b *testing.B) {
+			hub := NewHub()
+			for i := 0; i < n; i++ {
+				c := &Client{GameID: "g1", UserID: benchName(i), Send: make(chan []byte, 16)}
+				hub.register(c)
+			}
+			msg := []byte(`{"type":"sync_state"}`)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				hub.Broadcast("g1", msg)
+				drain(hub, "g1")
+			}
+		})
+	}
+}
+
+func drain(hub *Hub, gameID string) {
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+	for c := range hub.clients[gameID] {
+		select {
+		case <-c.Send:
+		default:
+		}
+	}
+}
+
+func benchName(n int) string {
+	const digits = "0123456789"
+	if n == 0 {
+		return "0"
+	}
+	var buf []byte
+	for n > 0 {
+		buf = append([]byte{digits[n%10]}, buf...)
+		n /= 10
+	}
+	return string(buf)
+}
@@ -0,0 +1,64 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// lengthCapInterval is how often the scheduler checks live games against
+// their configured HouseRules length cap.
+const lengthCapInterval = 5 * time.Second
+
+// gameLengthCapReachedEvent is broadcast to the table when a game is
+// auto-ended for hitting its length cap, so clients can show why the game
+// ended rather than the ordinary end-of-round summary.
+type gameLengthCapReachedEvent struct {
+	Type   string `json:"type"`
+	GameID string `json:"game_id"`
+	Status string `json:"status"`
+}
+
+// RunLengthCapScheduler polls games for HouseRules.MaxTurns/MaxDuration
+// violations and ends (or voids) them, the same staggered-scan shape as
+// RunNudgeScheduler so a capacity backlog doesn't starve games later in
+// iteration order.
+func RunLengthCapScheduler(hub *Hub, games *store.GameStore, hist *historian.Historian) {
+	ticker := time.NewTicker(lengthCapInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		all := games.All()
+		offset := nextTickOffset(len(all))
+		checked := 0
+		for i := range all {
+			if checked >= maxGamesCheckedPerTick {
+				break
+			}
+			checked++
+			g := all[(offset+i)%len(all)]
+
+			g.Mu.Lock()
+			ended := g.EnforceLengthCap()
+			status := g.Status
+			gameID := g.ID
+			g.Mu.Unlock()
+			if !ended {
+				continue
+			}
+
+			hist.Record(gameID, historian.EventGameLengthCapReached, map[string]interface{}{
+				"status": string(status),
+			})
+			if payload, err := json.Marshal(gameLengthCapReachedEvent{
+				Type:   "game_length_cap_reached",
+				GameID: gameID,
+				Status: string(status),
+			}); err == nil {
+				hub.Broadcast(gameID, payload)
+			}
+		}
+	}
+}
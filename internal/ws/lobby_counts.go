@@ -0,0 +1,50 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/lobby"
+)
+
+// lobbyCountInterval is how often lobby_update crowd-size events are
+// broadcast: frequent enough for lobby browsing to feel live, but still
+// bounded so a busy lobby list doesn't spam every connected client.
+const lobbyCountInterval = 3 * time.Second
+
+// lobbyUpdateEvent carries live crowd-size counts so lobby browsers and
+// in-lobby UIs can show how popular a game is before it starts. Invites
+// reports each explicitly invited user's pending/accepted status (see
+// Lobby.InviteMember), distinct from Players/Spectators since an invitee
+// hasn't joined at all yet while pending.
+type lobbyUpdateEvent struct {
+	Type       string                        `json:"type"`
+	GameID     string                        `json:"game_id"`
+	Players    int                           `json:"players"`
+	Spectators int                           `json:"spectators"`
+	Invites    map[string]lobby.InviteStatus `json:"invites,omitempty"`
+}
+
+// RunLobbyCountScheduler broadcasts a lobby_update event for every open
+// lobby on lobbyCountInterval. It blocks until the process exits and is
+// meant to be started once in a background goroutine.
+func RunLobbyCountScheduler(hub *Hub, lobbies map[string]*lobby.Lobby) {
+	ticker := time.NewTicker(lobbyCountInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for gameID, l := range lobbies {
+			players, spectators := l.Counts()
+			payload, err := json.Marshal(lobbyUpdateEvent{
+				Type:       "lobby_update",
+				GameID:     gameID,
+				Players:    players,
+				Spectators: spectators,
+				Invites:    l.InviteStatuses(),
+			})
+			if err == nil {
+				hub.BroadcastNonEssential(gameID, payload)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// nudgeInterval is how often the scheduler checks for players who are past
+// half their turn timer and due a reminder.
+const nudgeInterval = 2 * time.Second
+
+// turnReminderEvent is sent privately to the current player when they've
+// used more than half their turn timer without acting.
+type turnReminderEvent struct {
+	Type      string `json:"type"`
+	GameID    string `json:"game_id"`
+	Remaining int    `json:"remaining_seconds"`
+}
+
+// RunNudgeScheduler polls games for players who are past half their turn
+// timer and sends each one a single turn_reminder event for that turn. It
+// blocks until the process exits and is meant to be started once in a
+// background goroutine.
+func RunNudgeScheduler(hub *Hub, games *store.GameStore) {
+	ticker := time.NewTicker(nudgeInterval)
+	defer ticker.Stop()
+	nudgedThisTurn := make(map[string]int) // gameID -> TurnIdx already nudged
+
+	for range ticker.C {
+		all := games.All()
+		offset := nextTickOffset(len(all))
+		checked := 0
+		for i := range all {
+			if checked >= maxGamesCheckedPerTick {
+				break
+			}
+			checked++
+			g := all[(offset+i)%len(all)]
+			g.Mu.Lock()
+			if g.Timer == nil || !g.TurnPastHalf() {
+				g.Mu.Unlock()
+				continue
+			}
+			if prev, ok := nudgedThisTurn[g.ID]; ok && prev == g.TurnIdx {
+				g.Mu.Unlock()
+				continue
+			}
+			player := g.CurrentPlayer()
+			if player == nil {
+				g.Mu.Unlock()
+				continue
+			}
+			nudgedThisTurn[g.ID] = g.TurnIdx
+			g.NudgeCounts[player.UserID]++
+			remaining := int(g.TurnRemaining().Seconds())
+			userID := player.UserID
+			gameID := g.ID
+			dormant := g.IsDormant(userID)
+			g.Mu.Unlock()
+
+			payload, err := json.Marshal(turnReminderEvent{
+				Type:      "turn_reminder",
+				GameID:    gameID,
+				Remaining: remaining,
+			})
+			if err == nil {
+				hub.SendToUser(gameID, userID, payload)
+			}
+			if dormant && hub.OnDormantTurnApproaching != nil {
+				hub.OnDormantTurnApproaching(gameID, userID)
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package ws
+
+import (
+	"encoding/json"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/models"
+)
+
+// privateSyncStateEvent restores a reconnecting client's view of what it's
+// legitimately allowed to know: the cards it has peeked or been shown. It
+// is only ever sent via SendToUser, never broadcast, since another
+// player's knowledge is exactly what this must not leak. KnownCards is
+// keyed by this player's per-viewer alias for each card (see
+// game.AliasedKnowledge), not the card's real, globally-stable ID, so a
+// client can't cross-reference its knowledge against anything else on the
+// wire that might reference the same physical card.
+type privateSyncStateEvent struct {
+	Type       string                 `json:"type"`
+	GameID     string                 `json:"game_id"`
+	KnownCards map[string]models.Card `json:"known_cards"`
+}
+
+// BuildPrivateSyncState marshals userID's private knowledge of g into a
+// private_sync_state event, for delivery on connect/reconnect so a client
+// doesn't lose peeked information across a dropped connection.
+func BuildPrivateSyncState(g *game.Game, userID string) ([]byte, error) {
+	return json.Marshal(privateSyncStateEvent{
+		Type:       "private_sync_state",
+		GameID:     g.ID,
+		KnownCards: g.AliasedKnowledge(userID),
+	})
+}
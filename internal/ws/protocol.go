@@ -0,0 +1,44 @@
+package ws
+
+// ProtocolVersion identifies a version of the game websocket's event
+// schema, negotiated via the connection's Sec-WebSocket-Protocol
+// subprotocol list (see Upgrader.Subprotocols and NewClient).
+type ProtocolVersion string
+
+const (
+	// ProtocolV1 is the original, unversioned event schema: every client
+	// built before versioning landed speaks this implicitly, since it
+	// never sends a Sec-WebSocket-Protocol header at all. Kept supported
+	// indefinitely as the compatibility shim for those clients.
+	ProtocolV1 ProtocolVersion = "game.v1"
+	// ProtocolV2 adds, among other things, ActionEnvelope.ActionID
+	// dedupe/echo and the connection_stats/set_dormant event types.
+	ProtocolV2 ProtocolVersion = "game.v2"
+
+	// CurrentProtocolVersion is what a new client should request.
+	CurrentProtocolVersion = ProtocolV2
+)
+
+// SupportedProtocolVersions lists every version this server still
+// accepts, most preferred first; Upgrader.Subprotocols is set from this.
+var SupportedProtocolVersions = []ProtocolVersion{ProtocolV2, ProtocolV1}
+
+// SupportedProtocolStrings is SupportedProtocolVersions as plain strings,
+// the shape gorilla's websocket.Upgrader.Subprotocols wants.
+func SupportedProtocolStrings() []string {
+	out := make([]string, len(SupportedProtocolVersions))
+	for i, v := range SupportedProtocolVersions {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// SupportsProtocolVersion reports whether v is one this server accepts.
+func SupportsProtocolVersion(v ProtocolVersion) bool {
+	for _, supported := range SupportedProtocolVersions {
+		if supported == v {
+			return true
+		}
+	}
+	return false
+}
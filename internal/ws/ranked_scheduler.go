@@ -0,0 +1,89 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+	"github.com/jason-s-yu/cambia-service/internal/rating"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// rankedTickInterval is how often the scheduler checks ranked games for
+// completion.
+const rankedTickInterval = 5 * time.Second
+
+// gameEndEvent is broadcast to a ranked game's table once it ends, the
+// ranked-mode analog of circuitStandingsEvent: there's no per-game
+// standings table outside circuit mode, so this instead carries each
+// player's rating delta directly.
+type gameEndEvent struct {
+	Type         string             `json:"type"`
+	GameID       string             `json:"game_id"`
+	Scores       map[string]int     `json:"scores"`
+	RatingDeltas map[string]float64 `json:"rating_deltas"`
+	// Result is this game's signed outcome, omitted if signer is nil.
+	Result *game.SignedResult `json:"result,omitempty"`
+}
+
+// RunRankedScheduler polls non-circuit ranked games for completion and
+// folds their final scores into ratings, the ranked-mode equivalent of
+// RunCircuitScheduler's round-end handling. Circuit games are excluded:
+// they finalize ratings per round via ws.RunCircuitScheduler instead,
+// keyed on rating.ModeCircuit rather than player count. signer may be nil,
+// in which case game_end is broadcast unsigned, as before this field
+// existed.
+func RunRankedScheduler(hub *Hub, games *store.GameStore, hist *historian.Historian, ratings *rating.Store, signer *game.ResultSigner) {
+	ticker := time.NewTicker(rankedTickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		all := games.All()
+		offset := nextTickOffset(len(all))
+		checked := 0
+		for i := range all {
+			if checked >= maxGamesCheckedPerTick {
+				break
+			}
+			checked++
+			g := all[(offset+i)%len(all)]
+			if g.Mode != game.ModeRanked {
+				continue
+			}
+
+			g.Mu.Lock()
+			if !g.Status.Terminal() || g.RatingsFinalized {
+				g.Mu.Unlock()
+				continue
+			}
+			g.RatingsFinalized = true
+			scores := finalScores(g, hist)
+			gameID := g.ID
+			mode := rating.ModeForPlayerCount(len(g.Players))
+			seed := g.Audit.RNGSeed
+			var result *game.SignedResult
+			if signer != nil {
+				signed := signer.Sign(gameID, seed, scores)
+				g.SignedResult = &signed
+				result = &signed
+			}
+			g.Mu.Unlock()
+
+			deltas := rating.FinalizeRatings(ratings, mode, scores)
+			hist.Record(gameID, historian.EventGameEnded, map[string]interface{}{
+				"scores":        scores,
+				"rating_deltas": deltas,
+			})
+			if payload, err := json.Marshal(gameEndEvent{
+				Type:         "game_end",
+				GameID:       gameID,
+				Scores:       scores,
+				RatingDeltas: deltas,
+				Result:       result,
+			}); err == nil {
+				hub.Broadcast(gameID, payload)
+			}
+		}
+	}
+}
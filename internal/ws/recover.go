@@ -0,0 +1,24 @@
+package ws
+
+import (
+	"log"
+	"runtime/debug"
+)
+
+// recoverGameHandler wraps a message handler so a panic while processing
+// one game's action can't take down the whole server or, via a shared
+// goroutine, any other game. It logs a structured record of the panic and
+// its stack so on-call can diagnose it after the fact.
+func recoverGameHandler(handle func(*Client, []byte)) func(*Client, []byte) {
+	return func(c *Client, msg []byte) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf(
+					"ws: recovered panic in game=%s user=%s: %v\n%s",
+					c.GameID, c.UserID, r, debug.Stack(),
+				)
+			}
+		}()
+		handle(c, msg)
+	}
+}
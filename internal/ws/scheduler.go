@@ -0,0 +1,24 @@
+package ws
+
+import "sync/atomic"
+
+// maxGamesCheckedPerTick staggers timer work across games so that one busy tick
+// doesn't starve games later in iteration order. RunNudgeScheduler consults
+// this to only do a bounded amount of work per tick when under heavy load.
+const maxGamesCheckedPerTick = 2000
+
+// tickCursor is used to round-robin the starting point of each scheduler
+// tick across games, rather than always starting from the same map
+// iteration order.
+var tickCursor atomic.Uint64
+
+// nextTickOffset returns a rotating offset used to pick where a scheduler
+// tick starts scanning, so that under a capacity backlog every game still
+// gets serviced over time rather than only the first maxGamesCheckedPerTick
+// in iteration order.
+func nextTickOffset(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return int(tickCursor.Add(1) % uint64(n))
+}
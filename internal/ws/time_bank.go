@@ -0,0 +1,67 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// timeBankBroadcastInterval is how often the current player's remaining
+// time bank is pushed out, for games that have time banks configured.
+const timeBankBroadcastInterval = 3 * time.Second
+
+// timeBankUpdateEvent reports the current player's remaining time bank, so
+// clients can render a live countdown instead of polling for it.
+type timeBankUpdateEvent struct {
+	Type             string `json:"type"`
+	GameID           string `json:"game_id"`
+	UserID           string `json:"user_id"`
+	RemainingSeconds int    `json:"remaining_seconds"`
+}
+
+// RunTimeBankScheduler broadcasts a time_bank_update event for the current
+// player of every game with HouseRules.TimeBank configured. It blocks
+// until the process exits and is meant to be started once in a background
+// goroutine.
+func RunTimeBankScheduler(hub *Hub, games *store.GameStore) {
+	ticker := time.NewTicker(timeBankBroadcastInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		all := games.All()
+		offset := nextTickOffset(len(all))
+		checked := 0
+		for i := range all {
+			if checked >= maxGamesCheckedPerTick {
+				break
+			}
+			checked++
+			g := all[(offset+i)%len(all)]
+			g.Mu.Lock()
+			if g.HouseRules.TimeBank.Reserve <= 0 {
+				g.Mu.Unlock()
+				continue
+			}
+			player := g.CurrentPlayer()
+			if player == nil {
+				g.Mu.Unlock()
+				continue
+			}
+			remaining := int(g.TimeBankFor(player.UserID).RemainingNow().Seconds())
+			userID := player.UserID
+			gameID := g.ID
+			g.Mu.Unlock()
+
+			payload, err := json.Marshal(timeBankUpdateEvent{
+				Type:             "time_bank_update",
+				GameID:           gameID,
+				UserID:           userID,
+				RemainingSeconds: remaining,
+			})
+			if err == nil {
+				hub.Broadcast(gameID, payload)
+			}
+		}
+	}
+}
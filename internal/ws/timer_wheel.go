@@ -0,0 +1,189 @@
+package ws
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// wheelTick is the wheel's resolution: a deadline fires within wheelTick of
+// when it's due (either side, depending on where the ticker is in its
+// period), the same tradeoff RunNudgeScheduler's 2s ticker makes for nudges,
+// just tighter since expirations are user-visible.
+const wheelTick = 250 * time.Millisecond
+
+// wheelSlots sizes one full revolution of the wheel at wheelTick resolution
+// (10 minutes); a deadline further out than that wraps and waits for a
+// later revolution via wheelEntry.rotations.
+const wheelSlots = 2400
+
+// wheelEntry is one scheduled deadline. fire is cleared (set to nil) by
+// Cancel rather than removed from its slot immediately, since removing it
+// would mean scanning the slot; tick skips nil-fire entries instead.
+type wheelEntry struct {
+	key       string
+	deadline  time.Time
+	rotations int
+	fire      func()
+}
+
+// TimerWheel is a single-level hierarchical timing wheel: O(1) Schedule and
+// Cancel, and firing costs only the entries actually due each tick rather
+// than scanning every live game/lobby, which is the point of it existing
+// at all — see RunTurnExpiryScheduler and RunLobbyCountdownScheduler for
+// the two things currently driving it (turn timeouts and lobby countdown
+// expiry).
+type TimerWheel struct {
+	mu     sync.Mutex
+	slots  [wheelSlots][]*wheelEntry
+	index  map[string]*wheelEntry
+	cursor int
+	stats  timerWheelStats
+}
+
+// timerWheelStats accumulates TimerWheel.Stats()'s counters. lastLag/maxLag
+// store a time.Duration bit-for-bit via int64(d), the same atomic-pair
+// pattern as historian.commitNanos, since Stats() is read far more often
+// than tick() fires and shouldn't contend with the wheel's own mutex.
+type timerWheelStats struct {
+	fired    int64 // atomic
+	canceled int64 // atomic
+	lastLag  int64 // atomic nanoseconds
+	maxLag   int64 // atomic nanoseconds
+}
+
+func (s *timerWheelStats) observeLag(d time.Duration) {
+	atomic.StoreInt64(&s.lastLag, int64(d))
+	for {
+		cur := atomic.LoadInt64(&s.maxLag)
+		if int64(d) <= cur {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&s.maxLag, cur, int64(d)) {
+			return
+		}
+	}
+}
+
+// TimerWheelStats is a snapshot of TimerWheel.Stats().
+type TimerWheelStats struct {
+	Scheduled     int
+	FiredTotal    int64
+	CanceledTotal int64
+	LastLagMs     float64
+	MaxLagMs      float64
+}
+
+// NewTimerWheel returns an empty, unstarted TimerWheel; call Run to start
+// advancing it.
+func NewTimerWheel() *TimerWheel {
+	return &TimerWheel{
+		index: make(map[string]*wheelEntry),
+	}
+}
+
+// Schedule (re)schedules fire to run within wheelTick of deadline, keyed by
+// key. Scheduling an already-scheduled key cancels the prior entry first,
+// so a caller can simply reschedule on every observed state change instead
+// of checking whether key already has an entry.
+func (w *TimerWheel) Schedule(key string, deadline time.Time, fire func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cancelLocked(key)
+
+	// tick advances the cursor before reading a slot, so the nearest slot
+	// that still fires is cursor+1.
+	ticks := int((deadline.Sub(time.Now()) + wheelTick - 1) / wheelTick)
+	if ticks < 1 {
+		ticks = 1
+	}
+	slot := (w.cursor + ticks) % wheelSlots
+	entry := &wheelEntry{key: key, deadline: deadline, rotations: (ticks - 1) / wheelSlots, fire: fire}
+	w.slots[slot] = append(w.slots[slot], entry)
+	w.index[key] = entry
+}
+
+// Cancel removes key's scheduled entry, if any. It's safe to call for a key
+// that was never scheduled or has already fired.
+func (w *TimerWheel) Cancel(key string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cancelLocked(key)
+}
+
+func (w *TimerWheel) cancelLocked(key string) {
+	entry, ok := w.index[key]
+	if !ok {
+		return
+	}
+	entry.fire = nil
+	delete(w.index, key)
+	atomic.AddInt64(&w.stats.canceled, 1)
+}
+
+// Run advances the wheel every wheelTick until stop is closed, blocking the
+// calling goroutine; callers invoke it as `go wheel.Run(stop)`, the same
+// convention as historian.Historian.RunLagAlertJob.
+func (w *TimerWheel) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(wheelTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.tick()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// tick advances the cursor by one slot and fires everything due there,
+// re-bucketing entries that still have revolutions left to wait. fire
+// callbacks run after the wheel's mutex is released, so a callback that
+// acquires a game's own Mu (the common case) can never deadlock against a
+// concurrent Schedule/Cancel call on the wheel itself.
+func (w *TimerWheel) tick() {
+	w.mu.Lock()
+	w.cursor = (w.cursor + 1) % wheelSlots
+	due := w.slots[w.cursor]
+	w.slots[w.cursor] = nil
+
+	now := time.Now()
+	var ready []*wheelEntry
+	for _, entry := range due {
+		if entry.fire == nil {
+			continue // canceled
+		}
+		if entry.rotations > 0 {
+			entry.rotations--
+			w.slots[w.cursor] = append(w.slots[w.cursor], entry)
+			continue
+		}
+		delete(w.index, entry.key)
+		ready = append(ready, entry)
+	}
+	w.mu.Unlock()
+
+	for _, entry := range ready {
+		if lag := now.Sub(entry.deadline); lag > 0 {
+			w.stats.observeLag(lag)
+		}
+		atomic.AddInt64(&w.stats.fired, 1)
+		entry.fire()
+	}
+}
+
+// Stats returns a snapshot of the wheel's load and firing latency, exposed
+// via metrics.Handlers.
+func (w *TimerWheel) Stats() TimerWheelStats {
+	w.mu.Lock()
+	scheduled := len(w.index)
+	w.mu.Unlock()
+	return TimerWheelStats{
+		Scheduled:     scheduled,
+		FiredTotal:    atomic.LoadInt64(&w.stats.fired),
+		CanceledTotal: atomic.LoadInt64(&w.stats.canceled),
+		LastLagMs:     float64(atomic.LoadInt64(&w.stats.lastLag)) / float64(time.Millisecond),
+		MaxLagMs:      float64(atomic.LoadInt64(&w.stats.maxLag)) / float64(time.Millisecond),
+	}
+}
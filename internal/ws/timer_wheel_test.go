@@ -0,0 +1,73 @@
+package ws
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestTimerWheelFiresScheduledEntry verifies Schedule eventually fires its
+// callback once the wheel has ticked past the deadline.
+func TestTimerWheelFiresScheduledEntry(t *testing.T) {
+	wheel := NewTimerWheel()
+	stop := make(chan struct{})
+	defer close(stop)
+	go wheel.Run(stop)
+
+	var fired atomic.Bool
+	wheel.Schedule("k1", time.Now().Add(2*wheelTick), func() { fired.Store(true) })
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !fired.Load() && time.Now().Before(deadline) {
+		time.Sleep(wheelTick)
+	}
+	if !fired.Load() {
+		t.Fatal("scheduled entry never fired")
+	}
+	if stats := wheel.Stats(); stats.FiredTotal != 1 {
+		t.Fatalf("FiredTotal = %d, want 1", stats.FiredTotal)
+	}
+}
+
+// TestTimerWheelCancelPreventsFire verifies Cancel stops a scheduled entry
+// from firing even after its deadline passes.
+func TestTimerWheelCancelPreventsFire(t *testing.T) {
+	wheel := NewTimerWheel()
+	stop := make(chan struct{})
+	defer close(stop)
+	go wheel.Run(stop)
+
+	var fired atomic.Bool
+	wheel.Schedule("k1", time.Now().Add(2*wheelTick), func() { fired.Store(true) })
+	wheel.Cancel("k1")
+
+	time.Sleep(6 * wheelTick)
+	if fired.Load() {
+		t.Fatal("canceled entry fired anyway")
+	}
+	if stats := wheel.Stats(); stats.CanceledTotal != 1 {
+		t.Fatalf("CanceledTotal = %d, want 1", stats.CanceledTotal)
+	}
+}
+
+// TestTimerWheelRescheduleReplacesPriorEntry verifies scheduling the same
+// key twice cancels the first entry rather than firing both.
+func TestTimerWheelRescheduleReplacesPriorEntry(t *testing.T) {
+	wheel := NewTimerWheel()
+	stop := make(chan struct{})
+	defer close(stop)
+	go wheel.Run(stop)
+
+	var fireCount atomic.Int32
+	wheel.Schedule("k1", time.Now().Add(2*wheelTick), func() { fireCount.Add(1) })
+	wheel.Schedule("k1", time.Now().Add(2*wheelTick), func() { fireCount.Add(1) })
+
+	deadline := time.Now().Add(2 * time.Second)
+	for fireCount.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(wheelTick)
+	}
+	time.Sleep(6 * wheelTick) // give a stray duplicate fire a chance to show up
+	if got := fireCount.Load(); got != 1 {
+		t.Fatalf("fireCount = %d, want 1", got)
+	}
+}
@@ -0,0 +1,130 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jason-s-yu/cambia-service/internal/game"
+	"github.com/jason-s-yu/cambia-service/internal/historian"
+	"github.com/jason-s-yu/cambia-service/internal/models"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+// turnExpirySyncInterval mirrors countdownSyncInterval: frequent enough to
+// notice a new turn or a canceled one promptly, with wheelTick governing
+// how precisely the deadline itself fires.
+const turnExpirySyncInterval = 1 * time.Second
+
+// turnExpiryKey namespaces a turn's wheel entry by game and TurnIdx, so an
+// advanced turn naturally gets a fresh key rather than colliding with the
+// one it replaced, and so it can't collide with lobbyCountdownKey even
+// though both schedulers share one TimerWheel.
+func turnExpiryKey(gameID string, turnIdx int) string {
+	return fmt.Sprintf("turn:%s:%d", gameID, turnIdx)
+}
+
+// playerTimedOutEvent is broadcast publicly after TimeoutPolicy.Action is
+// applied on a timed-out player's behalf. Discarded is omitted for
+// TimeoutSkip, which moves no cards; for the other two actions the card is
+// already public knowledge, same as any other discard (see
+// playerDiscardedEvent in cmd/server/main.go).
+type playerTimedOutEvent struct {
+	Type      string             `json:"type"`
+	GameID    string             `json:"game_id"`
+	UserID    string             `json:"user_id"`
+	Action    game.TimeoutAction `json:"action"`
+	Discarded *models.Card       `json:"discarded,omitempty"`
+}
+
+// RunTurnExpiryScheduler keeps wheel in sync with every active game's
+// current-turn deadline and, when one fires, applies
+// Game.TimeoutPolicy.Action on the stalled player's behalf, advances the
+// turn, and broadcasts what happened. It blocks until the process exits
+// and is meant to be started once in a background goroutine.
+func RunTurnExpiryScheduler(wheel *TimerWheel, hub *Hub, games *store.GameStore, hist *historian.Historian) {
+	ticker := time.NewTicker(turnExpirySyncInterval)
+	defer ticker.Stop()
+
+	scheduled := make(map[string]int) // gameID -> TurnIdx currently scheduled
+	for range ticker.C {
+		for _, g := range games.All() {
+			g.Mu.Lock()
+			active := (g.Status == game.StatusActive || g.Status == game.StatusFinalRound) && g.Timer != nil && len(g.Players) > 0
+			var turnIdx int
+			var deadline time.Time
+			if active {
+				turnIdx = g.TurnIdx
+				deadline = time.Now().Add(g.TurnRemaining())
+			}
+			g.Mu.Unlock()
+
+			gameID := g.ID
+			prevTurnIdx, wasScheduled := scheduled[gameID]
+			if !active {
+				if wasScheduled {
+					wheel.Cancel(turnExpiryKey(gameID, prevTurnIdx))
+					delete(scheduled, gameID)
+				}
+				continue
+			}
+			if wasScheduled && prevTurnIdx == turnIdx {
+				continue // already scheduled for this turn; Advance/Reset will change TurnIdx when it's due for rescheduling
+			}
+			if wasScheduled {
+				wheel.Cancel(turnExpiryKey(gameID, prevTurnIdx))
+			}
+			scheduled[gameID] = turnIdx
+			wheel.Schedule(turnExpiryKey(gameID, turnIdx), deadline, func() {
+				fireTurnExpiry(hub, games, hist, gameID, turnIdx)
+			})
+		}
+	}
+}
+
+// fireTurnExpiry re-verifies gameID's turn hasn't already advanced (the
+// player acted just before the wheel fired) before applying
+// Game.TimeoutPolicy.Action, since the wheel fires outside g.Mu.
+func fireTurnExpiry(hub *Hub, games *store.GameStore, hist *historian.Historian, gameID string, turnIdx int) {
+	g, ok := games.Get(gameID)
+	if !ok {
+		return
+	}
+	g.Mu.Lock()
+	if g.TurnIdx != turnIdx || (g.Status != game.StatusActive && g.Status != game.StatusFinalRound) {
+		g.Mu.Unlock()
+		return
+	}
+	player := g.CurrentPlayer()
+	if player == nil {
+		g.Mu.Unlock()
+		return
+	}
+	userID := player.UserID
+	outcome, err := g.ApplyTimeoutExpiry(userID)
+	if err != nil {
+		g.Mu.Unlock()
+		return
+	}
+	g.Advance()
+	g.Mu.Unlock()
+
+	payload := map[string]interface{}{
+		"user_id": userID,
+		"action":  string(outcome.Action),
+	}
+	event := playerTimedOutEvent{
+		Type:   "player_timed_out",
+		GameID: gameID,
+		UserID: userID,
+		Action: outcome.Action,
+	}
+	if outcome.Discarded != nil {
+		event.Discarded = outcome.Discarded
+		payload["discarded_card_id"] = outcome.Discarded.ID
+	}
+	hist.Record(gameID, historian.EventTurnTimedOut, payload)
+	if msg, err := json.Marshal(event); err == nil {
+		hub.Broadcast(gameID, msg)
+	}
+}
@@ -0,0 +1,49 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ActionEnvelope is the common shape every inbound WS payload must match
+// before it's handed to a specific action handler. Validating this shape up
+// front means individual handlers can assume a well-formed envelope instead
+// of each re-deriving their own defenses against malformed input.
+type ActionEnvelope struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+	// ActionID, if set by the client, identifies this specific action so
+	// it can be safely retried after a transient error without
+	// double-applying: the server dedupes by ActionID per connection (see
+	// Client.SeenActionID) and echoes it back in the resulting event or
+	// error response. Optional; a client that doesn't send one gets no
+	// dedupe protection.
+	ActionID string `json:"action_id,omitempty"`
+}
+
+// maxActionTypeLen bounds the Type field so a client can't smuggle an
+// oversized string into routing logic or logs.
+const maxActionTypeLen = 64
+
+// maxActionIDLen bounds ActionID the same way maxActionTypeLen bounds Type.
+const maxActionIDLen = 64
+
+// ValidateEnvelope parses and sanity-checks a raw inbound frame, returning
+// the envelope on success or a descriptive error identifying what was
+// wrong with the payload.
+func ValidateEnvelope(raw []byte) (ActionEnvelope, error) {
+	var env ActionEnvelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return ActionEnvelope{}, fmt.Errorf("malformed payload: %w", err)
+	}
+	if env.Type == "" {
+		return ActionEnvelope{}, fmt.Errorf("missing action type")
+	}
+	if len(env.Type) > maxActionTypeLen {
+		return ActionEnvelope{}, fmt.Errorf("action type exceeds %d bytes", maxActionTypeLen)
+	}
+	if len(env.ActionID) > maxActionIDLen {
+		return ActionEnvelope{}, fmt.Errorf("action_id exceeds %d bytes", maxActionIDLen)
+	}
+	return env, nil
+}
@@ -0,0 +1,48 @@
+// Package e2e contains black-box tests that exercise the server's HTTP API
+// over real network sockets. Run with `go test ./test/e2e/...`; each test
+// starts its own httptest server, so none of them needs the services in
+// docker-compose.test.yml or skips without them.
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jason-s-yu/cambia-service/internal/api"
+	"github.com/jason-s-yu/cambia-service/internal/models"
+	"github.com/jason-s-yu/cambia-service/internal/store"
+)
+
+func TestProfileEndpointRoundTrip(t *testing.T) {
+	users := store.NewUserStore()
+	users.Put(&models.User{ID: "u1", Username: "alice"})
+	handlers := &api.ProfileHandlers{Users: users}
+
+	srv := httptest.NewServer(http.HandlerFunc(handlers.GetProfile))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?user_id=u1")
+	if err != nil {
+		t.Fatalf("GET profile: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestProfileEndpointNotFound(t *testing.T) {
+	handlers := &api.ProfileHandlers{Users: store.NewUserStore()}
+	srv := httptest.NewServer(http.HandlerFunc(handlers.GetProfile))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?user_id=missing")
+	if err != nil {
+		t.Fatalf("GET profile: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+}